@@ -0,0 +1,167 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApprovalAnnotation is an alternative to the approval of the spec, for reviewers using kubectl annotate
+const ApprovalAnnotation = "localpvcleaner.io/approval"
+
+// Approval is the decision of a reviewer about the deletion of a PV
+// +kubebuilder:validation:Enum=Approved;Rejected
+type Approval string
+
+const (
+	// Approved lets the controller delete the PV
+	Approved Approval = "Approved"
+	// Rejected keeps the PV
+	Rejected Approval = "Rejected"
+)
+
+// Phase is the lifecycle phase of an OrphanedVolume
+type Phase string
+
+const (
+	// PhaseDetected is set when the PV became a deletion candidate and waits for a review
+	PhaseDetected Phase = "Detected"
+	// PhaseApproved is set when the deletion was approved for the current evidence
+	PhaseApproved Phase = "Approved"
+	// PhaseRejected is set when the deletion was rejected for the current evidence
+	PhaseRejected Phase = "Rejected"
+	// PhaseDeleted is set once the PV got deleted
+	PhaseDeleted Phase = "Deleted"
+	// PhaseExpired is set when the evidence changed after the detection or the review, a new review is needed
+	PhaseExpired Phase = "Expired"
+)
+
+// OrphanedVolumeSpec defines the desired state of OrphanedVolume
+type OrphanedVolumeSpec struct {
+	// PersistentVolumeName is the name of the candidate PV
+	PersistentVolumeName string `json:"persistentVolumeName"`
+
+	// Approval is the decision of a reviewer about the deletion of the PV. It is cleared by the controller
+	// when the evidence changes.
+	// +optional
+	Approval Approval `json:"approval,omitempty"`
+}
+
+// OrphanedVolumeStatus defines the observed state of OrphanedVolume
+type OrphanedVolumeStatus struct {
+	// Phase is the lifecycle phase of the OrphanedVolume
+	// +optional
+	Phase Phase `json:"phase,omitempty"`
+
+	// Cause is why the PV is a deletion candidate, orphaned or released-ttl
+	// +optional
+	Cause string `json:"cause,omitempty"`
+
+	// Action is what the controller does once the deletion is approved, delete or force-delete
+	// +optional
+	Action string `json:"action,omitempty"`
+
+	// Node is the node the PV is bound to
+	// +optional
+	Node string `json:"node,omitempty"`
+
+	// StorageClass is the storage class of the PV
+	// +optional
+	StorageClass string `json:"storageClass,omitempty"`
+
+	// Claim is the namespace/name of the claim of the PV
+	// +optional
+	Claim string `json:"claim,omitempty"`
+
+	// Evidence is the facts supporting the deletion
+	// +optional
+	Evidence map[string]string `json:"evidence,omitempty"`
+
+	// EvidenceHash identifies the evidence, an approval is only valid for the evidence it was given for
+	// +optional
+	EvidenceHash string `json:"evidenceHash,omitempty"`
+
+	// ReviewedEvidenceHash identifies the evidence the current approval was given for
+	// +optional
+	ReviewedEvidenceHash string `json:"reviewedEvidenceHash,omitempty"`
+
+	// DetectedAt is when the PV became a deletion candidate
+	// +optional
+	DetectedAt *metav1.Time `json:"detectedAt,omitempty"`
+
+	// ReviewedAt is when the controller observed the current approval
+	// +optional
+	ReviewedAt *metav1.Time `json:"reviewedAt,omitempty"`
+
+	// NextMaintenanceWindow is when the next maintenance window opens, while the deletions are held outside of them
+	// +optional
+	NextMaintenanceWindow *metav1.Time `json:"nextMaintenanceWindow,omitempty"`
+
+	// LastTransitionTime is when the phase last changed
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Message is a human readable explanation of the phase
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=ov
+// +kubebuilder:printcolumn:name="PV",type=string,JSONPath=`.spec.persistentVolumeName`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Cause",type=string,JSONPath=`.status.cause`
+// +kubebuilder:printcolumn:name="Node",type=string,JSONPath=`.status.node`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// OrphanedVolume is a PV the controller wants to delete, waiting for the approval of a reviewer
+type OrphanedVolume struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrphanedVolumeSpec   `json:"spec,omitempty"`
+	Status OrphanedVolumeStatus `json:"status,omitempty"`
+}
+
+// Approval returns the decision of the reviewer, from the spec or else from the ApprovalAnnotation
+func (v *OrphanedVolume) Approval() Approval {
+	if v.Spec.Approval != "" {
+		return v.Spec.Approval
+	}
+	for _, approval := range []Approval{Approved, Rejected} {
+		if strings.EqualFold(v.Annotations[ApprovalAnnotation], string(approval)) {
+			return approval
+		}
+	}
+	return ""
+}
+
+// +kubebuilder:object:root=true
+
+// OrphanedVolumeList contains a list of OrphanedVolume
+type OrphanedVolumeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrphanedVolume `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OrphanedVolume{}, &OrphanedVolumeList{})
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/audit"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/backup"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/controller"
+)
+
+// Results of the clean command that are not decisions of the controller
+const (
+	cleanResultCapped   = "capped"
+	cleanResultDeclined = "declined"
+	cleanResultPaused   = "paused"
+)
+
+// cleanOptions are the flags of the clean command
+type cleanOptions struct {
+	kubeconfig      string
+	dryRun          bool
+	yes             bool
+	maxDeletions    int
+	pushgateway     string
+	pushJob         string
+	backupStore     string
+	auditSinks      []string
+	requireApproval bool
+	pauseConfigMap  string
+	policyWebhook   policyWebhookOptions
+	decision        decisionOptions
+}
+
+// cleanResult counts the PVs of a clean pass by result, a decision of the controller,
+// capped for candidates over --max-deletions or declined when the confirmation was refused
+type cleanResult map[string]int
+
+// runClean runs a single cleanup pass over all PVs and exits
+func runClean(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var opts cleanOptions
+	flags := pflag.NewFlagSet("clean", pflag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to the kubeconfig, the in-cluster config is used when empty.")
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "Report what would be deleted without making actual changes.")
+	flags.BoolVarP(&opts.yes, "yes", "y", false, "Delete the candidates without asking for confirmation.")
+	flags.IntVar(&opts.maxDeletions, "max-deletions", 0,
+		"Maximum number of PVs deleted in a single pass, 0 means unlimited.")
+	flags.StringVar(&opts.pushgateway, "pushgateway", "",
+		"URL of a Prometheus Pushgateway receiving the counts of the pass, nothing is pushed when empty.")
+	flags.StringVar(&opts.pushJob, "push-job", "local-pv-cleaner-clean", "Job name of the pushed metrics.")
+	flags.StringVar(&opts.backupStore, "backup-store", "",
+		"Store receiving the PV and PVC manifests before a PV is deleted: configmap:<namespace> or dir:<path>.")
+	flags.StringSliceVar(&opts.auditSinks, "audit-sinks", nil,
+		"Comma-separated list of audit sinks receiving a JSON record per destructive action.")
+	flags.BoolVar(&opts.requireApproval, "require-approval", false,
+		"Hold every deletion until a reviewer approves the OrphanedVolume created for the PV, as the controller does.")
+	flags.StringVar(&opts.pauseConfigMap, "pause-configmap", "",
+		"ConfigMap, as namespace/name, whose "+controller.PauseKey+" key stops the pass while it is true.")
+	opts.policyWebhook.addFlags(flags)
+	opts.decision.addFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	result, err := cleanCluster(ctx, opts, stdin, stdout)
+	if opts.pushgateway != "" && result != nil {
+		if pushErr := pushCleanResult(opts.pushgateway, opts.pushJob, result, start); pushErr != nil {
+			_, _ = fmt.Fprintf(stderr, "unable to push metrics: %v\n", pushErr)
+			return 1
+		}
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "clean failed: %v\n", err)
+		return 1
+	}
+	if result["error"] > 0 || result[cleanResultDeclined] > 0 || result[cleanResultPaused] > 0 {
+		return 1
+	}
+	return 0
+}
+
+// cleanCluster starts a cache with the indexes of the controller and runs the clean pass on it
+func cleanCluster(ctx context.Context, opts cleanOptions, stdin io.Reader, out io.Writer) (cleanResult, error) {
+	pauseConfigMap, err := controller.ParsePauseConfigMap(opts.pauseConfigMap)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := restConfig(opts.kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	c, err := cluster.New(cfg, func(o *cluster.Options) {
+		o.Scheme = scheme
+		o.Cache = controller.CacheOptions(nil, opts.decision.storageClassNames, pauseConfigMap)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := controller.IndexFields(ctx, c.GetFieldIndexer()); err != nil {
+		return nil, err
+	}
+	cacheCtx, stopCache := context.WithCancel(ctx)
+	defer stopCache()
+	go func() {
+		_ = c.Start(cacheCtx)
+	}()
+	if !c.GetCache().WaitForCacheSync(ctx) {
+		return nil, fmt.Errorf("unable to sync the cache")
+	}
+
+	r, err := opts.decision.controller(c.GetClient())
+	if err != nil {
+		return nil, err
+	}
+	r.DryRun = opts.dryRun
+	r.RequireApproval = opts.requireApproval
+	r.PauseConfigMap = pauseConfigMap
+	r.APIReader = c.GetAPIReader()
+	r.Identity = "clean"
+	if r.PolicyHook, err = opts.policyWebhook.client(r.Identity); err != nil {
+		return nil, err
+	}
+	if r.Audit, err = audit.NewSink(opts.auditSinks, 5*time.Second); err != nil {
+		return nil, err
+	}
+	if opts.backupStore != "" {
+		if r.Backup, err = backup.NewStore(opts.backupStore, c.GetClient(), c.GetAPIReader(),
+			backup.Retention{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return clean(ctx, r, opts, stdin, out)
+}
+
+// clean assesses every PV, asks for confirmation and then reconciles the candidates once.
+// PVs waiting out the released TTL are reconciled too, to record since when they are released.
+func clean(ctx context.Context, r *controller.PVCleanupController, opts cleanOptions, stdin io.Reader,
+	out io.Writer) (cleanResult, error) {
+	var pvList corev1.PersistentVolumeList
+	if err := r.Client.List(ctx, &pvList); err != nil {
+		return nil, err
+	}
+	sort.Slice(pvList.Items, func(i, j int) bool {
+		return pvList.Items[i].Name < pvList.Items[j].Name
+	})
+
+	result := cleanResult{}
+	var candidates []controller.Decision
+	for _, pv := range pvList.Items {
+		decision, err := r.Assess(ctx, pv)
+		if err != nil {
+			return result, fmt.Errorf("assessing PV %s: %w", pv.Name, err)
+		}
+		switch decision.Verdict {
+		case controller.VerdictDelete:
+			candidates = append(candidates, decision)
+		case controller.VerdictGrace:
+			decision, err := r.ReconcileOnce(ctx, pv.Name)
+			if err != nil {
+				return result, fmt.Errorf("reconciling PV %s: %w", pv.Name, err)
+			}
+			result[decision]++
+		case controller.VerdictHold:
+			result["hold"]++
+		}
+	}
+
+	if opts.maxDeletions > 0 && len(candidates) > opts.maxDeletions {
+		result[cleanResultCapped] = len(candidates) - opts.maxDeletions
+		candidates = candidates[:opts.maxDeletions]
+	}
+	if len(candidates) == 0 {
+		_, _ = fmt.Fprintln(out, "No PV to delete.")
+		return result, nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "PV\tSTORAGE CLASS\tNODE\tREASON")
+	for _, a := range candidates {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.PV, a.StorageClass, a.Node, a.Reason)
+	}
+	if err := w.Flush(); err != nil {
+		return result, err
+	}
+	if result[cleanResultCapped] > 0 {
+		_, _ = fmt.Fprintf(out, "%d more PVs are left for a later pass by --max-deletions.\n", result[cleanResultCapped])
+	}
+
+	if paused, err := stopIfPaused(ctx, r, candidates, result, out); paused || err != nil {
+		return result, err
+	}
+	if !opts.dryRun && !opts.yes && !confirm(stdin, out, fmt.Sprintf("Delete %d PVs?", len(candidates))) {
+		result[cleanResultDeclined] = len(candidates)
+		_, _ = fmt.Fprintln(out, "Aborted, no PV deleted.")
+		return result, nil
+	}
+
+	for i, a := range candidates {
+		// the deletions may get paused during the pass, including while waiting for the confirmation
+		if paused, err := stopIfPaused(ctx, r, candidates[i:], result, out); paused || err != nil {
+			return result, err
+		}
+		decision, err := r.ReconcileOnce(ctx, a.PV)
+		if err != nil {
+			_, _ = fmt.Fprintf(out, "Failed to delete PV %s: %v\n", a.PV, err)
+		}
+		result[decision]++
+	}
+	_, _ = fmt.Fprintf(out, "Deleted %d PVs, %d would be deleted, %d held, %d failed.\n",
+		result["delete"], result["dry-run"], result["hold"], result["error"])
+
+	return result, nil
+}
+
+// stopIfPaused reports whether the deletions are paused by the pause ConfigMap, counting the remaining candidates
+// as paused when they are
+func stopIfPaused(ctx context.Context, r *controller.PVCleanupController, remaining []controller.Decision,
+	result cleanResult, out io.Writer) (bool, error) {
+	message, err := r.PausedMessage(ctx)
+	if err != nil || message == "" {
+		return false, err
+	}
+	result[cleanResultPaused] = len(remaining)
+	_, _ = fmt.Fprintf(out, "%s, %d PVs left for a later pass.\n", message, len(remaining))
+	return true, nil
+}
+
+// confirm asks a yes or no question and reports whether it was answered with yes
+func confirm(stdin io.Reader, out io.Writer, question string) bool {
+	_, _ = fmt.Fprintf(out, "%s [y/N]: ", question)
+	answer, _ := bufio.NewReader(stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// pushCleanResult pushes the counts of a clean pass to a Pushgateway, replacing those of the previous pass
+func pushCleanResult(url, job string, result cleanResult, start time.Time) error {
+	pvs := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "local_pv_cleaner_clean_pvs",
+		Help: "Number of PVs of the last clean pass by result",
+	}, []string{"result"})
+	for _, name := range []string{"delete", "dry-run", "hold", "grace", "error", cleanResultCapped, cleanResultDeclined,
+		cleanResultPaused} {
+		pvs.WithLabelValues(name).Set(float64(result[name]))
+	}
+	duration := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "local_pv_cleaner_clean_duration_seconds",
+		Help: "Duration of the last clean pass",
+	})
+	duration.Set(time.Since(start).Seconds())
+	completion := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "local_pv_cleaner_clean_last_completion_timestamp_seconds",
+		Help: "Unix time of the completion of the last clean pass",
+	})
+	completion.SetToCurrentTime()
+
+	return push.New(url, job).Collector(pvs).Collector(duration).Collector(completion).Push()
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kavinraja-g/local-pv-cleaner/api/v1alpha1"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/controller"
+)
+
+func TestClean(t *testing.T) {
+	retained := newLocalPV("pv-retained", "topolvm", "node-gone")
+	retained.Annotations = map[string]string{controller.RetainUntilAnnotation: "2999-01-01T00:00:00Z"}
+
+	var tests = []struct {
+		name         string
+		opts         cleanOptions
+		objects      []client.Object
+		stdin        string
+		wantDeleted  []string
+		wantResult   cleanResult
+		wantContains string
+	}{
+		{
+			name:        "Confirmed interactively",
+			stdin:       "y\n",
+			wantDeleted: []string{"pv-orphan-1", "pv-orphan-2"},
+			wantResult:  cleanResult{"delete": 2, "hold": 1},
+		},
+		{
+			name:         "Declined interactively",
+			stdin:        "\n",
+			wantResult:   cleanResult{cleanResultDeclined: 2, "hold": 1},
+			wantContains: "Aborted, no PV deleted.",
+		},
+		{
+			name:         "No answer is a refusal",
+			wantResult:   cleanResult{cleanResultDeclined: 2, "hold": 1},
+			wantContains: "Delete 2 PVs? [y/N]",
+		},
+		{
+			name:         "Capped by --max-deletions",
+			opts:         cleanOptions{yes: true, maxDeletions: 1},
+			wantDeleted:  []string{"pv-orphan-1"},
+			wantResult:   cleanResult{"delete": 1, cleanResultCapped: 1, "hold": 1},
+			wantContains: "1 more PVs are left for a later pass",
+		},
+		{
+			name:       "Dry-run deletes nothing without asking",
+			opts:       cleanOptions{dryRun: true},
+			wantResult: cleanResult{"dry-run": 2, "hold": 1},
+		},
+		{
+			name:         "Held until approved with --require-approval",
+			opts:         cleanOptions{yes: true, requireApproval: true},
+			wantResult:   cleanResult{"hold": 3},
+			wantContains: "Deleted 0 PVs, 0 would be deleted, 3 held, 0 failed.",
+		},
+		{
+			name: "Stopped by the pause ConfigMap",
+			opts: cleanOptions{yes: true, pauseConfigMap: "ops/pause"},
+			objects: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ops", Name: "pause"},
+				Data:       map[string]string{controller.PauseKey: "true"},
+			}},
+			wantResult:   cleanResult{cleanResultPaused: 2, "hold": 1},
+			wantContains: "Deletions are paused by ConfigMap ops/pause, 2 PVs left for a later pass.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := crFake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(
+					newLocalPV("pv-orphan-1", "topolvm", "node-gone"),
+					newLocalPV("pv-orphan-2", "topolvm", "node-gone"),
+					newLocalPV("pv-healthy", "topolvm", "node-01"),
+					retained.DeepCopy(),
+					&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-01"}},
+				).
+				WithObjects(tt.objects...).
+				WithStatusSubresource(&v1alpha1.OrphanedVolume{}).
+				WithIndex(&storagev1.VolumeAttachment{}, "spec.source.persistentVolumeName", func(client.Object) []string {
+					return nil
+				}).
+				Build()
+			opts := tt.opts
+			opts.decision = decisionOptions{
+				nodeSelectorKeys:   []string{testNodeSelectorKey},
+				storageClassNames:  []string{"topolvm"},
+				reclaimPolicyNames: []string{"Retain"},
+			}
+			r, err := opts.decision.controller(c)
+			require.NoError(t, err)
+			r.DryRun = opts.dryRun
+			r.RequireApproval = opts.requireApproval
+			r.PauseConfigMap, err = controller.ParsePauseConfigMap(opts.pauseConfigMap)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			result, err := clean(ctx, r, opts, strings.NewReader(tt.stdin), &out)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantResult, result)
+			assert.Contains(t, out.String(), tt.wantContains)
+
+			var deleted []string
+			for _, name := range []string{"pv-orphan-1", "pv-orphan-2", "pv-healthy", "pv-retained"} {
+				err := c.Get(ctx, client.ObjectKey{Name: name}, &corev1.PersistentVolume{})
+				if apierrors.IsNotFound(err) {
+					deleted = append(deleted, name)
+				}
+			}
+			assert.Equal(t, tt.wantDeleted, deleted)
+		})
+	}
+}
+
+func TestPushCleanResult(t *testing.T) {
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		data, _ := io.ReadAll(req.Body)
+		method, path, body = req.Method, req.URL.Path, string(data)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	err := pushCleanResult(server.URL, "nightly-clean", cleanResult{"delete": 3, "hold": 1}, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/metrics/job/nightly-clean", path)
+	assert.Contains(t, body, "local_pv_cleaner_clean_pvs")
+	assert.Contains(t, body, "local_pv_cleaner_clean_last_completion_timestamp_seconds")
+}
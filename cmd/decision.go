@@ -0,0 +1,170 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/celpolicy"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/controller"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/window"
+)
+
+// decisionOptions are the flags of the commands running the decision logic of the controller outside of it
+type decisionOptions struct {
+	nodeSelectorKeys   []string
+	storageClassNames  []string
+	reclaimPolicyNames []string
+	phaseNames         []string
+	releasedTTL        time.Duration
+	namespaceOptIn     bool
+	selector           string
+	orphanRule         string
+	celCostLimit       uint64
+	maintenanceWindows []string
+}
+
+// addFlags registers the decision flags, with the same names and defaults as the controller flags
+func (o *decisionOptions) addFlags(flags *pflag.FlagSet) {
+	flags.StringSliceVar(&o.nodeSelectorKeys, "node-selector-keys", []string{"topology.topolvm.io/node"},
+		"Comma-separated list of labels used in PV node affinity to determine the node name.")
+	flags.StringSliceVar(&o.storageClassNames, "storage-class-names", []string{"topolvm"},
+		"Comma-separated list of StorageClass Names used to filter the PVs.")
+	flags.StringSliceVar(&o.reclaimPolicyNames, "reclaim-policies", []string{"Retain"},
+		"Comma-separated list of managed PV reclaim policies (Retain, Delete).")
+	flags.StringSliceVar(&o.phaseNames, "pv-phases", []string{"Bound", "Released", "Failed", "Available"},
+		"Comma-separated list of managed PV phases (Bound, Released, Failed, Available).")
+	flags.DurationVar(&o.releasedTTL, "released-ttl", 0,
+		"Hand Retain PVs on healthy nodes over to their provisioner for deletion once they have been Released for this "+
+			"long, 0 disables it.")
+	flags.BoolVar(&o.namespaceOptIn, "namespace-opt-in", false,
+		"Only manage PVs whose claim namespace is labelled with "+controller.NamespaceOptInLabel+"=true.")
+	flags.StringVar(&o.selector, "selector", "",
+		"CEL expression restricting the managed PVs, evaluated over pv, pvc, ns, node, recorded and now.")
+	flags.StringVar(&o.orphanRule, "orphan-rule", "",
+		"CEL expression an orphaned PV must match to be deleted, evaluated over pv, pvc, ns, node, recorded and now.")
+	flags.Uint64Var(&o.celCostLimit, "cel-cost-limit", celpolicy.DefaultCostLimit,
+		"Maximum runtime cost of a single evaluation of the CEL expressions.")
+	flags.StringArrayVar(&o.maintenanceWindows, "maintenance-window", nil,
+		"Window in which PVs may be deleted, repeat the flag for several windows. PVs are deleted at any time when "+
+			"no window is set.")
+}
+
+// policyFile is the YAML form of the decision options, the fields it sets override the flags
+type policyFile struct {
+	NodeSelectorKeys   []string         `json:"nodeSelectorKeys,omitempty"`
+	StorageClassNames  []string         `json:"storageClassNames,omitempty"`
+	ReclaimPolicies    []string         `json:"reclaimPolicies,omitempty"`
+	Phases             []string         `json:"phases,omitempty"`
+	ReleasedTTL        *metav1.Duration `json:"releasedTTL,omitempty"`
+	NamespaceOptIn     *bool            `json:"namespaceOptIn,omitempty"`
+	Selector           *string          `json:"selector,omitempty"`
+	OrphanRule         *string          `json:"orphanRule,omitempty"`
+	MaintenanceWindows []string         `json:"maintenanceWindows,omitempty"`
+}
+
+// withPolicyFile returns the options overridden by the fields set in the given policy file
+func (o decisionOptions) withPolicyFile(path string) (decisionOptions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return o, err
+	}
+	var policy policyFile
+	if err := yaml.UnmarshalStrict(data, &policy); err != nil {
+		return o, err
+	}
+
+	if policy.NodeSelectorKeys != nil {
+		o.nodeSelectorKeys = policy.NodeSelectorKeys
+	}
+	if policy.StorageClassNames != nil {
+		o.storageClassNames = policy.StorageClassNames
+	}
+	if policy.ReclaimPolicies != nil {
+		o.reclaimPolicyNames = policy.ReclaimPolicies
+	}
+	if policy.Phases != nil {
+		o.phaseNames = policy.Phases
+	}
+	if policy.ReleasedTTL != nil {
+		o.releasedTTL = policy.ReleasedTTL.Duration
+	}
+	if policy.NamespaceOptIn != nil {
+		o.namespaceOptIn = *policy.NamespaceOptIn
+	}
+	if policy.Selector != nil {
+		o.selector = *policy.Selector
+	}
+	if policy.OrphanRule != nil {
+		o.orphanRule = *policy.OrphanRule
+	}
+	if policy.MaintenanceWindows != nil {
+		o.maintenanceWindows = policy.MaintenanceWindows
+	}
+	return o, nil
+}
+
+// controller returns a controller deciding with the given client, it is never started
+func (o *decisionOptions) controller(c client.Client) (*controller.PVCleanupController, error) {
+	reclaimPolicies, err := controller.ParseReclaimPolicies(o.reclaimPolicyNames)
+	if err != nil {
+		return nil, err
+	}
+	phases, err := controller.ParsePhases(o.phaseNames)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := compileExpression("selector", o.selector, o.celCostLimit)
+	if err != nil {
+		return nil, err
+	}
+	orphanRule, err := compileExpression("orphan-rule", o.orphanRule, o.celCostLimit)
+	if err != nil {
+		return nil, err
+	}
+	maintenanceWindows, err := window.ParseSchedule(o.maintenanceWindows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &controller.PVCleanupController{
+		Client:             c,
+		NodeSelectorKeys:   o.nodeSelectorKeys,
+		StorageClassNames:  o.storageClassNames,
+		ReclaimPolicies:    reclaimPolicies,
+		Phases:             phases,
+		ReleasedTTL:        o.releasedTTL,
+		NamespaceOptIn:     o.namespaceOptIn,
+		Selector:           selector,
+		OrphanRule:         orphanRule,
+		MaintenanceWindows: maintenanceWindows,
+	}, nil
+}
+
+// compileExpression compiles the named CEL expression, nil is returned for an empty source
+func compileExpression(name, source string, costLimit uint64) (*celpolicy.Expression, error) {
+	if source == "" {
+		return nil, nil
+	}
+	return celpolicy.Compile(name, source, costLimit)
+}
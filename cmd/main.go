@@ -17,6 +17,8 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
 	"flag"
 	"os"
@@ -29,6 +31,7 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -40,7 +43,15 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	"github.com/kavinraja-g/local-pv-cleaner/api/v1alpha1"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/audit"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/backup"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/celpolicy"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/cloudevents"
 	"github.com/kavinraja-g/local-pv-cleaner/internal/controller"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/notify"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/tracing"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/window"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -51,12 +62,26 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
 
 	// +kubebuilder:scaffold:scheme
 }
 
 // nolint:gocyclo
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "restore":
+			os.Exit(runRestore(os.Args[2:], os.Stdout, os.Stderr))
+		case "scan":
+			os.Exit(runScan(os.Args[2:], os.Stdout, os.Stderr))
+		case "clean":
+			os.Exit(runClean(os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+		case "simulate":
+			os.Exit(runSimulate(os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
+
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
@@ -68,6 +93,39 @@ func main() {
 	var nodeSelectorKeys []string
 	var storageClassNames []string
 	var requeueDuration time.Duration
+	var namespaceOptIn bool
+	var requireApproval bool
+	var pvLabelSelector string
+	var releasedTTL time.Duration
+	var inventoryMetrics bool
+	var tracingOpts tracing.Options
+	var auditSinks []string
+	var auditWebhookTimeout time.Duration
+	var identity string
+	var backupStore string
+	var notifyWebhooks, notifyKinds []string
+	var notifyOpts notify.Options
+	var notifySecretFile string
+	var cloudEventsOpts cloudevents.Options
+	var cloudEventsMode string
+	var cloudEventsTypes []string
+	var policyWebhook policyWebhookOptions
+	var backupRetention backup.Retention
+	var inventoryNodeLabel string
+	var sweepInterval time.Duration
+	var sweepJitter float64
+	var maxConcurrentReconciles int
+	var rateLimiterBaseDelay, rateLimiterMaxDelay time.Duration
+	var rateLimiterQPS float64
+	var rateLimiterBurst int
+	var mutationQPS float64
+	var mutationBurst int
+	var reclaimPolicyNames []string
+	var phaseNames []string
+	var selectorSource, orphanRuleSource string
+	var celCostLimit uint64
+	var maintenanceWindowSources []string
+	var pauseConfigMapName string
 
 	var tlsOpts []func(*tls.Config)
 	pflag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
@@ -96,6 +154,102 @@ func main() {
 		"Comma-separated list of StorageClass Names used to filter the PVs.")
 	pflag.DurationVar(&requeueDuration, "requeue-duration", 15*time.Minute,
 		"Duration for PV requeue if the node exists (e.g., 5m, 10m, 1h)")
+	pflag.StringSliceVar(&reclaimPolicyNames, "reclaim-policies", []string{"Retain"},
+		"Comma-separated list of PV reclaim policies managed by the controller (Retain, Delete).")
+	pflag.StringSliceVar(&phaseNames, "pv-phases", []string{"Bound", "Released", "Failed", "Available"},
+		"Comma-separated list of PV phases managed by the controller (Bound, Released, Failed, Available).")
+	pflag.DurationVar(&releasedTTL, "released-ttl", 0,
+		"Hand Retain PVs on healthy nodes over to their provisioner for deletion once they have been Released for this "+
+			"long (e.g., 24h), 0 disables it.")
+	pflag.BoolVar(&inventoryMetrics, "inventory-metrics", false,
+		"Expose the number and capacity of the local PVs per node and storage class, computed on every scrape.")
+	pflag.StringVar(&inventoryNodeLabel, "inventory-node-label", "",
+		"Aggregate the inventory metrics by this node label (e.g., node.kubernetes.io/instance-type) instead of by node name.")
+	pflag.DurationVar(&sweepInterval, "sweep-interval", 0,
+		"Interval of the periodic sweep over all PVs, which replaces the per-PV requeue when set (e.g., 10m).")
+	pflag.Float64Var(&sweepJitter, "sweep-jitter", 0.1,
+		"Maximum jitter factor added to the sweep interval (e.g., 0.1 for up to 10%).")
+	pflag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"Maximum number of PVs reconciled in parallel.")
+	pflag.DurationVar(&rateLimiterBaseDelay, "rate-limiter-base-delay", 5*time.Millisecond,
+		"Initial per-PV backoff after a failed reconcile.")
+	pflag.DurationVar(&rateLimiterMaxDelay, "rate-limiter-max-delay", 1000*time.Second,
+		"Maximum per-PV backoff after repeatedly failed reconciles.")
+	pflag.Float64Var(&rateLimiterQPS, "rate-limiter-qps", 10,
+		"Maximum rate of requeues per second over all PVs.")
+	pflag.IntVar(&rateLimiterBurst, "rate-limiter-burst", 100,
+		"Maximum burst of requeues over all PVs.")
+	pflag.Float64Var(&mutationQPS, "mutation-qps", 0,
+		"Maximum rate of API mutating operations (deletes and patches) per second, 0 means unlimited.")
+	pflag.IntVar(&mutationBurst, "mutation-burst", 10,
+		"Maximum burst of API mutating operations when --mutation-qps is set.")
+	pflag.StringVar(&pvLabelSelector, "pv-label-selector", "",
+		"Label selector restricting the PVs watched and cached by the controller, all PVs are watched when empty.")
+	pflag.BoolVar(&namespaceOptIn, "namespace-opt-in", false,
+		"Only manage PVs whose claim namespace is labelled with "+controller.NamespaceOptInLabel+"=true.")
+	pflag.BoolVar(&requireApproval, "require-approval", false,
+		"Hold every deletion until a reviewer approves the OrphanedVolume created for the PV.")
+	pflag.StringVar(&selectorSource, "selector", "",
+		"CEL expression restricting the managed PVs, evaluated over pv, pvc, ns, node, recorded and now.")
+	pflag.StringVar(&orphanRuleSource, "orphan-rule", "",
+		"CEL expression an orphaned PV must match to be deleted, evaluated over pv, pvc, ns, node, recorded and now.")
+	pflag.Uint64Var(&celCostLimit, "cel-cost-limit", celpolicy.DefaultCostLimit,
+		"Maximum runtime cost of a single evaluation of the CEL expressions.")
+	pflag.StringArrayVar(&maintenanceWindowSources, "maintenance-window", nil,
+		"Window in which PVs may be deleted, repeat the flag for several windows: \"<days> <HH:MM-HH:MM> [time zone]\" "+
+			"or \"cron <schedule> <duration> [time zone]\". PVs are deleted at any time when no window is set.")
+	pflag.StringVar(&pauseConfigMapName, "pause-configmap", "",
+		"ConfigMap, as namespace/name, whose "+controller.PauseKey+" key pauses every deletion at runtime while it is true.")
+	pflag.StringSliceVar(&auditSinks, "audit-sinks", nil,
+		"Comma-separated list of audit sinks receiving a JSON record per destructive action: stdout, file:<path> "+
+			"or an http(s) webhook URL.")
+	pflag.DurationVar(&auditWebhookTimeout, "audit-webhook-timeout", 5*time.Second,
+		"Timeout of the audit webhook requests.")
+	pflag.StringVar(&identity, "identity", "",
+		"Identity of this controller instance in the audit records, the hostname is used when empty.")
+	pflag.StringVar(&backupStore, "backup-store", "",
+		"Store receiving the PV and PVC manifests before a PV is deleted: configmap:<namespace> or dir:<path>.")
+	pflag.IntVar(&backupRetention.MaxCount, "backup-max-count", 1000,
+		"Maximum number of stored snapshots, the oldest ones are pruned first, 0 means unlimited.")
+	pflag.DurationVar(&backupRetention.MaxAge, "backup-max-age", 30*24*time.Hour,
+		"How long the snapshots are kept, 0 means forever.")
+	pflag.StringSliceVar(&notifyWebhooks, "notify-webhooks", nil,
+		"Comma-separated list of webhooks notified about the cleanup activity, each an URL optionally prefixed "+
+			"with its payload format (e.g., slack=https://hooks.slack.com/services/..., json=https://...).")
+	pflag.StringSliceVar(&notifyKinds, "notify-events", []string{"orphan-found", "deleted", "delete-failed", "dry-run"},
+		"Comma-separated list of notified events (orphan-found, deleted, delete-failed, dry-run).")
+	pflag.DurationVar(&notifyOpts.BatchInterval, "notify-batch-interval", 30*time.Second,
+		"How long events are collected before they are sent as a single message.")
+	pflag.IntVar(&notifyOpts.MaxBatchSize, "notify-max-batch-size", 50,
+		"Maximum number of events of a single message.")
+	pflag.Float64Var(&notifyOpts.RateLimit, "notify-rate-limit", 1,
+		"Maximum number of messages per second over all webhooks, 0 means unlimited.")
+	pflag.IntVar(&notifyOpts.MaxRetries, "notify-max-retries", 3,
+		"How often a message is retried after a network error, a 429 or a 5xx response.")
+	pflag.StringVar(&notifySecretFile, "notify-signing-secret-file", "",
+		"File holding the secret signing the webhook payloads with HMAC-SHA256, payloads are not signed when empty.")
+	pflag.StringVar(&cloudEventsOpts.Sink, "cloudevents-sink", "",
+		"HTTP URL receiving the cleanup lifecycle as CloudEvents, no event is emitted when empty.")
+	pflag.StringVar(&cloudEventsMode, "cloudevents-mode", string(cloudevents.ModeBinary),
+		"HTTP content mode of the CloudEvents: binary or structured.")
+	pflag.StringVar(&cloudEventsOpts.Source, "cloudevents-source", "local-pv-cleaner",
+		"Source attribute of the CloudEvents, e.g. an URI identifying the cluster.")
+	pflag.StringSliceVar(&cloudEventsTypes, "cloudevents-types",
+		[]string{"orphan-detected", "deleted", "skipped", "guard-tripped"},
+		"Comma-separated list of emitted CloudEvents (orphan-detected, deleted, skipped, guard-tripped).")
+	pflag.IntVar(&cloudEventsOpts.QueueSize, "cloudevents-queue-size", 1000,
+		"Number of CloudEvents queued while the sink is unavailable before new ones are dropped.")
+	pflag.DurationVar(&cloudEventsOpts.MaxEventAge, "cloudevents-max-event-age", 10*time.Minute,
+		"How long a CloudEvent is retried while the sink is unavailable before it is given up.")
+	pflag.DurationVar(&cloudEventsOpts.DrainTimeout, "cloudevents-drain-timeout", 10*time.Second,
+		"How long the queued CloudEvents are delivered on shutdown before the ones left are dropped.")
+	policyWebhook.addFlags(pflag.CommandLine)
+	pflag.StringVar(&tracingOpts.Endpoint, "otlp-endpoint", "",
+		"host:port of the OTLP gRPC collector receiving the reconcile traces, tracing is disabled when empty.")
+	pflag.BoolVar(&tracingOpts.Insecure, "otlp-insecure", false,
+		"Disable TLS towards the OTLP collector.")
+	pflag.Float64Var(&tracingOpts.SampleRatio, "trace-sample-ratio", 1,
+		"Fraction of the reconciles that are traced, between 0 and 1.")
 
 	opts := zap.Options{
 		// Development: true,
@@ -107,11 +261,112 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
-	// Print all flag values
+	// Print all flag values, without the secrets of the webhook and sink URLs
 	pflag.VisitAll(func(flag *pflag.Flag) {
-		setupLog.Info("Flag", flag.Name, flag.Value)
+		setupLog.Info("Flag", flag.Name, redactFlagValue(flag.Value.String()))
 	})
 
+	reclaimPolicies, err := controller.ParseReclaimPolicies(reclaimPolicyNames)
+	if err != nil {
+		setupLog.Error(err, "invalid --reclaim-policies")
+		os.Exit(1)
+	}
+	phases, err := controller.ParsePhases(phaseNames)
+	if err != nil {
+		setupLog.Error(err, "invalid --pv-phases")
+		os.Exit(1)
+	}
+
+	selector, err := compileExpression("selector", selectorSource, celCostLimit)
+	if err != nil {
+		setupLog.Error(err, "invalid --selector")
+		os.Exit(1)
+	}
+	orphanRule, err := compileExpression("orphan-rule", orphanRuleSource, celCostLimit)
+	if err != nil {
+		setupLog.Error(err, "invalid --orphan-rule")
+		os.Exit(1)
+	}
+	maintenanceWindows, err := window.ParseSchedule(maintenanceWindowSources)
+	if err != nil {
+		setupLog.Error(err, "invalid --maintenance-window")
+		os.Exit(1)
+	}
+
+	pauseConfigMap, err := controller.ParsePauseConfigMap(pauseConfigMapName)
+	if err != nil {
+		setupLog.Error(err, "invalid --pause-configmap")
+		os.Exit(1)
+	}
+
+	var pvSelector labels.Selector
+	if pvLabelSelector != "" {
+		pvSelector, err = labels.Parse(pvLabelSelector)
+		if err != nil {
+			setupLog.Error(err, "invalid --pv-label-selector")
+			os.Exit(1)
+		}
+	}
+
+	auditSink, err := audit.NewSink(auditSinks, auditWebhookTimeout)
+	if err != nil {
+		setupLog.Error(err, "invalid --audit-sinks")
+		os.Exit(1)
+	}
+	for _, spec := range notifyWebhooks {
+		webhook, err := notify.ParseWebhook(spec)
+		if err != nil {
+			setupLog.Error(err, "invalid --notify-webhooks")
+			os.Exit(1)
+		}
+		notifyOpts.Webhooks = append(notifyOpts.Webhooks, webhook)
+	}
+	for _, kind := range notifyKinds {
+		notifyOpts.Kinds = append(notifyOpts.Kinds, notify.Kind(kind))
+	}
+	if notifySecretFile != "" {
+		secret, err := os.ReadFile(notifySecretFile)
+		if err != nil {
+			setupLog.Error(err, "unable to read --notify-signing-secret-file")
+			os.Exit(1)
+		}
+		notifyOpts.SigningSecret = bytes.TrimSpace(secret)
+	}
+	notifier := notify.New(notifyOpts)
+
+	if cloudEventsOpts.Mode, err = cloudevents.ParseMode(cloudEventsMode); err != nil {
+		setupLog.Error(err, "invalid --cloudevents-mode")
+		os.Exit(1)
+	}
+	for _, name := range cloudEventsTypes {
+		eventType, err := cloudevents.ParseType(name)
+		if err != nil {
+			setupLog.Error(err, "invalid --cloudevents-types")
+			os.Exit(1)
+		}
+		cloudEventsOpts.Types = append(cloudEventsOpts.Types, eventType)
+	}
+	emitter, err := cloudevents.New(cloudEventsOpts)
+	if err != nil {
+		setupLog.Error(err, "invalid --cloudevents-sink")
+		os.Exit(1)
+	}
+
+	if identity == "" {
+		identity, _ = os.Hostname()
+	}
+	policyHook, err := policyWebhook.client(identity)
+	if err != nil {
+		setupLog.Error(err, "invalid --policy-webhook flags")
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracingOpts)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -203,6 +458,7 @@ func main() {
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  controller.CacheOptions(pvSelector, storageClassNames, pauseConfigMap),
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
@@ -214,19 +470,66 @@ func main() {
 		os.Exit(1)
 	}
 
+	pvBackupStore, err := backup.NewStore(backupStore, mgr.GetClient(), mgr.GetAPIReader(), backupRetention)
+	if err != nil {
+		setupLog.Error(err, "invalid --backup-store")
+		os.Exit(1)
+	}
+
 	if err = (&controller.PVCleanupController{
-		Client:            mgr.GetClient(),
-		Scheme:            mgr.GetScheme(),
-		DryRun:            dryRun,
-		NodeSelectorKeys:  nodeSelectorKeys,
-		StorageClassNames: storageClassNames,
-		RequeueDuration:   requeueDuration,
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		DryRun:                  dryRun,
+		NodeSelectorKeys:        nodeSelectorKeys,
+		StorageClassNames:       storageClassNames,
+		RequeueDuration:         requeueDuration,
+		ReclaimPolicies:         reclaimPolicies,
+		Phases:                  phases,
+		ReleasedTTL:             releasedTTL,
+		Selector:                selector,
+		OrphanRule:              orphanRule,
+		MaintenanceWindows:      maintenanceWindows,
+		PauseConfigMap:          pauseConfigMap,
+		InventoryMetrics:        inventoryMetrics,
+		InventoryNodeLabel:      inventoryNodeLabel,
+		APIReader:               mgr.GetAPIReader(),
+		Backup:                  pvBackupStore,
+		Notifier:                notifier,
+		CloudEvents:             emitter,
+		PolicyHook:              policyHook,
+		Audit:                   auditSink,
+		Identity:                identity,
+		SweepInterval:           sweepInterval,
+		SweepJitter:             sweepJitter,
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		RateLimiterBaseDelay:    rateLimiterBaseDelay,
+		RateLimiterMaxDelay:     rateLimiterMaxDelay,
+		RateLimiterQPS:          rateLimiterQPS,
+		RateLimiterBurst:        rateLimiterBurst,
+		Budget:                  controller.NewMutationBudget(mutationQPS, mutationBurst),
+		NamespaceOptIn:          namespaceOptIn,
+		RequireApproval:         requireApproval,
+		Recorder:                mgr.GetEventRecorderFor("local-pv-cleaner"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "local-pv-cleaner")
 		os.Exit(1)
 	}
 	// +kubebuilder:scaffold:builder
 
+	if notifier != nil {
+		if err := mgr.Add(notifier); err != nil {
+			setupLog.Error(err, "unable to add notifier")
+			os.Exit(1)
+		}
+	}
+
+	if emitter != nil {
+		if err := mgr.Add(emitter); err != nil {
+			setupLog.Error(err, "unable to add CloudEvents emitter")
+			os.Exit(1)
+		}
+	}
+
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
 		if err := mgr.Add(metricsCertWatcher); err != nil {
@@ -253,7 +556,14 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if shutdownErr := shutdownTracing(shutdownCtx); shutdownErr != nil {
+		setupLog.Error(shutdownErr, "problem flushing traces")
+	}
+	cancel()
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
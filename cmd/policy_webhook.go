@@ -0,0 +1,61 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/policyhook"
+)
+
+// policyWebhookOptions are the flags of the policy decision webhook, shared by the controller and the clean command
+type policyWebhookOptions struct {
+	opts          policyhook.Options
+	failurePolicy string
+}
+
+// addFlags registers the policy webhook flags
+func (o *policyWebhookOptions) addFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.opts.URL, "policy-webhook-url", "",
+		"HTTP(S) URL reviewing every deletion before it happens, no deletion is reviewed when empty.")
+	flags.DurationVar(&o.opts.Timeout, "policy-webhook-timeout", 5*time.Second,
+		"Timeout of a single policy webhook review.")
+	flags.StringVar(&o.failurePolicy, "policy-webhook-failure-policy", string(policyhook.FailClosed),
+		"What to do when the policy webhook fails: closed defers the deletion, open allows it.")
+	flags.StringVar(&o.opts.CAFile, "policy-webhook-ca-file", "",
+		"File holding the CA bundle verifying the policy webhook, the system roots are used when empty.")
+	flags.StringVar(&o.opts.CertFile, "policy-webhook-cert-file", "",
+		"File holding the client certificate presented to the policy webhook.")
+	flags.StringVar(&o.opts.KeyFile, "policy-webhook-key-file", "",
+		"File holding the key of the client certificate presented to the policy webhook.")
+	flags.DurationVar(&o.opts.CacheTTL, "policy-webhook-cache-ttl", 0,
+		"How long allow and deny answers are reused for unchanged evidence, answers are not cached when zero.")
+}
+
+// client returns the policy webhook client, nil when no URL is configured
+func (o *policyWebhookOptions) client(identity string) (*policyhook.Client, error) {
+	failurePolicy, err := policyhook.ParseFailurePolicy(o.failurePolicy)
+	if err != nil {
+		return nil, err
+	}
+	opts := o.opts
+	opts.FailurePolicy = failurePolicy
+	opts.Identity = identity
+	return policyhook.New(opts)
+}
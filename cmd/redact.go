@@ -0,0 +1,48 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/url"
+	"regexp"
+)
+
+// urlPattern matches the URLs within a flag value, including those of list values and of prefixed specs such as
+// "slack=https://..."
+var urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s,\]]+`)
+
+// redactedURLPart replaces the parts of an URL that may carry a secret
+const redactedURLPart = "REDACTED"
+
+// redactFlagValue hides the credentials, paths and queries of the URLs in a flag value before it gets logged,
+// webhook URLs such as the Slack ones carry their token in the path
+func redactFlagValue(value string) string {
+	return urlPattern.ReplaceAllStringFunc(value, func(raw string) string {
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" {
+			return redactedURLPart
+		}
+		redacted := &url.URL{Scheme: u.Scheme, Host: u.Host}
+		if u.User != nil {
+			redacted.User = url.User(redactedURLPart)
+		}
+		if (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+			redacted.Path = "/" + redactedURLPart
+		}
+		return redacted.String()
+	})
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/backup"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/controller"
+)
+
+// restoreOptions are the flags of the restore command
+type restoreOptions struct {
+	kubeconfig string
+	store      string
+	list       bool
+	pv         string
+	snapshot   string
+	withPVC    bool
+	optOut     bool
+	dryRun     bool
+}
+
+// restConfig returns the config of the given kubeconfig, or the default config when empty
+func restConfig(kubeconfig string) (*rest.Config, error) {
+	if kubeconfig != "" {
+		return clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	return ctrl.GetConfig()
+}
+
+// newClient returns a client for the given kubeconfig, or the default config when empty
+func newClient(kubeconfig string) (client.Client, error) {
+	cfg, err := restConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme})
+}
+
+// runRestore recreates a deleted PV, and optionally its claim, from a stored snapshot
+func runRestore(args []string, stdout, stderr io.Writer) int {
+	var opts restoreOptions
+	flags := pflag.NewFlagSet("restore", pflag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to the kubeconfig, the in-cluster config is used when empty.")
+	flags.StringVar(&opts.store, "backup-store", "",
+		"Store holding the snapshots: configmap:<namespace> or dir:<path>.")
+	flags.BoolVar(&opts.list, "list", false, "List the stored snapshots instead of restoring one.")
+	flags.StringVar(&opts.pv, "pv", "", "Restore the latest snapshot of this PV.")
+	flags.StringVar(&opts.snapshot, "snapshot", "", "Restore the snapshot with this name.")
+	flags.BoolVar(&opts.withPVC, "with-pvc", false, "Also recreate the claim of the PV when it no longer exists.")
+	flags.BoolVar(&opts.optOut, "opt-out", true,
+		"Annotate the restored PV with "+controller.SkipAnnotation+", so that the controller does not delete it again "+
+			"while its node is missing.")
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "Print the objects that would be created instead of creating them.")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if opts.store == "" || (!opts.list && (opts.pv == "") == (opts.snapshot == "")) {
+		_, _ = fmt.Fprintln(stderr, "restore requires --backup-store and either --list, --pv or --snapshot")
+		return 2
+	}
+
+	c, err := newClient(opts.kubeconfig)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "unable to create client: %v\n", err)
+		return 1
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := restore(ctx, c, opts, stdout); err != nil {
+		_, _ = fmt.Fprintf(stderr, "restore failed: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// restore runs the restore command against the given client
+func restore(ctx context.Context, c client.Client, opts restoreOptions, out io.Writer) error {
+	store, err := backup.NewStore(opts.store, c, c, backup.Retention{})
+	if err != nil {
+		return err
+	}
+
+	if opts.list {
+		entries, err := store.List(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "SNAPSHOT\tPV\tTAKEN AT")
+		for _, entry := range entries {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Name, entry.PV, entry.TakenAt.UTC().Format(time.RFC3339))
+		}
+		return w.Flush()
+	}
+
+	name := opts.snapshot
+	if name == "" {
+		entry, err := backup.Latest(ctx, store, opts.pv)
+		if err != nil {
+			return err
+		}
+		name = entry.Name
+	}
+	snapshot, err := store.Load(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	var existing corev1.PersistentVolume
+	err = c.Get(ctx, client.ObjectKey{Name: snapshot.PersistentVolume.Name}, &existing)
+	if err == nil {
+		return fmt.Errorf("PV %s already exists", existing.Name)
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	var liveClaim *corev1.PersistentVolumeClaim
+	if claim := snapshot.PersistentVolume.Spec.ClaimRef; claim != nil {
+		var pvc corev1.PersistentVolumeClaim
+		err := c.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: claim.Name}, &pvc)
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		if err == nil {
+			liveClaim = &pvc
+		}
+	}
+
+	pv := backup.RestorePV(snapshot, liveClaim)
+	if opts.optOut {
+		metav1.SetMetaDataAnnotation(&pv.ObjectMeta, controller.SkipAnnotation, "true")
+	}
+	objects := []client.Object{pv}
+	if opts.withPVC && liveClaim == nil {
+		if pvc := backup.RestorePVC(snapshot); pvc != nil {
+			objects = append(objects, pvc)
+		} else {
+			return errors.New("snapshot holds no claim, recreate the PV without --with-pvc")
+		}
+	}
+
+	for _, obj := range objects {
+		if opts.dryRun {
+			// typed objects carry no apiVersion and kind, which kubectl apply needs
+			gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+			if err != nil {
+				return err
+			}
+			obj.GetObjectKind().SetGroupVersionKind(gvk)
+			data, err := yaml.Marshal(obj)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(out, "---\n%s", data)
+			continue
+		}
+		if err := c.Create(ctx, obj); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "Restored %T %s from snapshot %s\n", obj, client.ObjectKeyFromObject(obj), name)
+	}
+
+	return nil
+}
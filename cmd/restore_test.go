@@ -0,0 +1,141 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/backup"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/controller"
+)
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	takenAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	snapshot := &backup.Snapshot{
+		TakenAt: takenAt,
+		PersistentVolume: corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-1", UID: "pv-uid", ResourceVersion: "42"},
+			Spec: corev1.PersistentVolumeSpec{
+				StorageClassName: "topolvm",
+				ClaimRef:         &corev1.ObjectReference{Namespace: "team-a", Name: "data", UID: "old-pvc-uid"},
+			},
+		},
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "data", UID: "old-pvc-uid"},
+		},
+	}
+
+	var tests = []struct {
+		name      string
+		opts      restoreOptions
+		objects   []client.Object
+		wantErr   bool
+		wantPVC   bool
+		wantClaim string
+		wantSkip  bool
+	}{
+		{name: "Restore latest snapshot of a PV", opts: restoreOptions{pv: "pv-1"}},
+		{name: "Opt the restored PV out", opts: restoreOptions{pv: "pv-1", optOut: true}, wantSkip: true},
+		{name: "Restore a named snapshot with its claim", opts: restoreOptions{snapshot: snapshot.Name(), withPVC: true},
+			wantPVC: true},
+		{
+			name: "Bind to the live claim",
+			opts: restoreOptions{pv: "pv-1", withPVC: true},
+			objects: []client.Object{&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "data", UID: "live-pvc-uid"},
+			}},
+			wantPVC:   true,
+			wantClaim: "live-pvc-uid",
+		},
+		{
+			name:    "PV still exists",
+			opts:    restoreOptions{pv: "pv-1"},
+			objects: []client.Object{&corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv-1"}}},
+			wantErr: true,
+		},
+		{name: "No snapshot of the PV", opts: restoreOptions{pv: "pv-2"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			store, err := backup.NewDirStore(dir, backup.Retention{})
+			require.NoError(t, err)
+			require.NoError(t, store.Save(ctx, snapshot))
+			c := crFake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+
+			tt.opts.store = "dir:" + dir
+			var out bytes.Buffer
+			err = restore(ctx, c, tt.opts, &out)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var pv corev1.PersistentVolume
+			require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "pv-1"}, &pv))
+			assert.Equal(t, tt.wantClaim, string(pv.Spec.ClaimRef.UID))
+			_, skipped := pv.Annotations[controller.SkipAnnotation]
+			assert.Equal(t, tt.wantSkip, skipped)
+			assert.Contains(t, out.String(), "Restored")
+
+			var pvc corev1.PersistentVolumeClaim
+			err = c.Get(ctx, client.ObjectKey{Namespace: "team-a", Name: "data"}, &pvc)
+			assert.Equal(t, tt.wantPVC, err == nil)
+			if tt.wantPVC && tt.wantClaim == "" {
+				assert.Equal(t, "pv-1", pvc.Spec.VolumeName)
+			}
+		})
+	}
+}
+
+func TestRestore_listAndDryRun(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := backup.NewDirStore(dir, backup.Retention{})
+	require.NoError(t, err)
+	snapshot := &backup.Snapshot{
+		TakenAt:          time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		PersistentVolume: corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv-1"}},
+	}
+	require.NoError(t, store.Save(ctx, snapshot))
+	c := crFake.NewClientBuilder().WithScheme(scheme).Build()
+
+	var out bytes.Buffer
+	require.NoError(t, restore(ctx, c, restoreOptions{store: "dir:" + dir, list: true}, &out))
+	assert.Contains(t, out.String(), snapshot.Name())
+	assert.Contains(t, out.String(), "2025-03-01T12:00:00Z")
+
+	out.Reset()
+	require.NoError(t, restore(ctx, c, restoreOptions{store: "dir:" + dir, pv: "pv-1", dryRun: true}, &out))
+	assert.Contains(t, out.String(), "name: pv-1")
+	assert.Contains(t, out.String(), "apiVersion: v1\nkind: PersistentVolume\n")
+	err = c.Get(ctx, client.ObjectKey{Name: "pv-1"}, &corev1.PersistentVolume{})
+	assert.Error(t, err, "Expected nothing to be created in dry-run mode")
+}
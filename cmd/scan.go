@@ -0,0 +1,159 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/controller"
+)
+
+// scanOrphansFound is the exit code of the scan command when orphaned PVs exist
+const scanOrphansFound = 3
+
+// scanOptions are the flags of the scan command
+type scanOptions struct {
+	kubeconfig string
+	output     string
+	all        bool
+	decision   decisionOptions
+}
+
+// scanReport is the JSON and YAML output of the scan command
+type scanReport struct {
+	PVs []controller.Decision `json:"pvs"`
+	// Orphaned is the number of managed PVs whose node is missing
+	Orphaned int `json:"orphaned"`
+}
+
+// runScan reports what the controller would do with every PV, without changing anything
+func runScan(args []string, stdout, stderr io.Writer) int {
+	var opts scanOptions
+	flags := pflag.NewFlagSet("scan", pflag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to the kubeconfig, the in-cluster config is used when empty.")
+	flags.StringVarP(&opts.output, "output", "o", "table", "Output format: table, json or yaml.")
+	flags.BoolVar(&opts.all, "all", false, "Also report the PVs the controller skips.")
+	opts.decision.addFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	switch opts.output {
+	case "table", "json", "yaml":
+	default:
+		_, _ = fmt.Fprintf(stderr, "unsupported output %q, expected table, json or yaml\n", opts.output)
+		return 2
+	}
+
+	c, err := newClient(opts.kubeconfig)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "unable to create client: %v\n", err)
+		return 1
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	report, err := scan(ctx, c, opts)
+	if err == nil {
+		err = writeScanReport(stdout, opts.output, report)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "scan failed: %v\n", err)
+		return 1
+	}
+	if report.Orphaned > 0 {
+		return scanOrphansFound
+	}
+	return 0
+}
+
+// scan assesses every PV with the decision logic of the controller
+func scan(ctx context.Context, c client.Client, opts scanOptions) (scanReport, error) {
+	r, err := opts.decision.controller(c)
+	if err != nil {
+		return scanReport{}, err
+	}
+
+	var pvList corev1.PersistentVolumeList
+	if err := c.List(ctx, &pvList); err != nil {
+		return scanReport{}, err
+	}
+	sort.Slice(pvList.Items, func(i, j int) bool {
+		return pvList.Items[i].Name < pvList.Items[j].Name
+	})
+
+	report := scanReport{PVs: []controller.Decision{}}
+	for _, pv := range pvList.Items {
+		decision, err := r.Assess(ctx, pv)
+		if err != nil {
+			return scanReport{}, fmt.Errorf("assessing PV %s: %w", pv.Name, err)
+		}
+		if decision.Verdict == controller.VerdictSkip && !opts.all {
+			continue
+		}
+		if decision.Orphaned() {
+			report.Orphaned++
+		}
+		report.PVs = append(report.PVs, decision)
+	}
+
+	return report, nil
+}
+
+// writeScanReport prints the report in the given format
+func writeScanReport(out io.Writer, format string, report scanReport) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case "yaml":
+		data, err := yaml.Marshal(report)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "PV\tSTORAGE CLASS\tNODE\tNODE STATUS\tVERDICT\tREASON\tMESSAGE")
+	for _, a := range report.PVs {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", a.PV, orDash(a.StorageClass), orDash(a.Node),
+			orDash(string(a.NodeStatus)), a.Verdict, a.Reason, a.Message)
+	}
+	return w.Flush()
+}
+
+// orDash returns a dash for empty table cells
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
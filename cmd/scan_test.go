@@ -0,0 +1,148 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/controller"
+)
+
+const testNodeSelectorKey = "topology.topolvm.io/node"
+
+func newLocalPV(name, storageClass, nodeName string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			StorageClassName:              storageClass,
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+			NodeAffinity: &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{Key: testNodeSelectorKey, Values: []string{nodeName}}},
+				}},
+			}},
+		},
+		Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+	}
+}
+
+func TestScan(t *testing.T) {
+	retained := newLocalPV("pv-retained", "topolvm", "node-gone")
+	retained.Annotations = map[string]string{controller.RetainUntilAnnotation: "2999-01-01T00:00:00Z"}
+
+	var tests = []struct {
+		name         string
+		all          bool
+		pvs          []*corev1.PersistentVolume
+		wantVerdicts map[string]controller.Verdict
+		wantOrphaned int
+	}{
+		{
+			name: "Orphaned PVs",
+			pvs: []*corev1.PersistentVolume{
+				newLocalPV("pv-orphan", "topolvm", "node-gone"),
+				newLocalPV("pv-healthy", "topolvm", "node-01"),
+				newLocalPV("pv-other-class", "openebs", "node-gone"),
+				retained,
+			},
+			wantVerdicts: map[string]controller.Verdict{
+				"pv-orphan":   controller.VerdictDelete,
+				"pv-healthy":  controller.VerdictKeep,
+				"pv-retained": controller.VerdictHold,
+			},
+			wantOrphaned: 2,
+		},
+		{
+			name: "Skipped PVs are reported with --all",
+			all:  true,
+			pvs: []*corev1.PersistentVolume{
+				newLocalPV("pv-healthy", "topolvm", "node-01"),
+				newLocalPV("pv-other-class", "openebs", "node-gone"),
+			},
+			wantVerdicts: map[string]controller.Verdict{
+				"pv-healthy":     controller.VerdictKeep,
+				"pv-other-class": controller.VerdictSkip,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			builder := crFake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-01"}})
+			for _, pv := range tt.pvs {
+				builder = builder.WithObjects(pv.DeepCopy())
+			}
+			opts := scanOptions{all: tt.all}
+			opts.decision = decisionOptions{
+				nodeSelectorKeys:   []string{testNodeSelectorKey},
+				storageClassNames:  []string{"topolvm"},
+				reclaimPolicyNames: []string{"Retain"},
+			}
+
+			report, err := scan(ctx, builder.Build(), opts)
+			require.NoError(t, err)
+			got := map[string]controller.Verdict{}
+			for _, a := range report.PVs {
+				got[a.PV] = a.Verdict
+			}
+			assert.Equal(t, tt.wantVerdicts, got)
+			assert.Equal(t, tt.wantOrphaned, report.Orphaned)
+		})
+	}
+}
+
+func TestWriteScanReport(t *testing.T) {
+	report := scanReport{
+		PVs: []controller.Decision{
+			{PV: "pv-orphan", StorageClass: "topolvm", Node: "node-gone", NodeStatus: controller.NodeStatusMissing,
+				Verdict: controller.VerdictDelete, Reason: "orphaned"},
+			{PV: "pv-other-class", StorageClass: "openebs", Verdict: controller.VerdictSkip, Reason: "storage-class"},
+		},
+		Orphaned: 1,
+	}
+
+	var table bytes.Buffer
+	require.NoError(t, writeScanReport(&table, "table", report))
+	assert.Equal(t, ""+
+		"PV              STORAGE CLASS  NODE       NODE STATUS  VERDICT  REASON         MESSAGE\n"+
+		"pv-orphan       topolvm        node-gone  missing      delete   orphaned       \n"+
+		"pv-other-class  openebs        -          -            skip     storage-class  \n", table.String())
+
+	var out bytes.Buffer
+	require.NoError(t, writeScanReport(&out, "json", report))
+	var fromJSON scanReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &fromJSON))
+	assert.Equal(t, report, fromJSON)
+
+	out.Reset()
+	require.NoError(t, writeScanReport(&out, "yaml", report))
+	var fromYAML scanReport
+	require.NoError(t, yaml.Unmarshal(out.Bytes(), &fromYAML))
+	assert.Equal(t, report, fromYAML)
+}
@@ -0,0 +1,371 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/controller"
+)
+
+// simulateOptions are the flags of the simulate command
+type simulateOptions struct {
+	snapshots          []string
+	at                 string
+	policyFile         string
+	previousPolicyFile string
+	output             string
+	all                bool
+	decision           decisionOptions
+}
+
+// simulatedVerdict is the verdict of a PV under the policy and, when compared, under the previous policy
+type simulatedVerdict struct {
+	controller.Decision `json:",inline"`
+	// Previous is the decision under the previous policy
+	Previous *controller.Decision `json:"previous,omitempty"`
+}
+
+// changed reports whether the previous policy gave another verdict
+func (v simulatedVerdict) changed() bool {
+	return v.Previous != nil && (v.Previous.Verdict != v.Verdict || v.Previous.Reason != v.Reason)
+}
+
+// simulateReport is the output of the simulate command
+type simulateReport struct {
+	At  time.Time          `json:"at"`
+	PVs []simulatedVerdict `json:"pvs"`
+	// Changed is the number of PVs whose verdict differs under the previous policy
+	Changed int `json:"changed,omitempty"`
+}
+
+// runSimulate runs the decision logic over exported objects at a simulated time, without an API server
+func runSimulate(args []string, stdout, stderr io.Writer) int {
+	var opts simulateOptions
+	flags := pflag.NewFlagSet("simulate", pflag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringSliceVarP(&opts.snapshots, "snapshot", "f", nil,
+		"Files or directories of PVs, PVCs, Nodes, Namespaces and StorageClasses as exported by kubectl get -o yaml, "+
+			"- reads from stdin.")
+	flags.StringVar(&opts.at, "at", "", "Simulated time as an RFC3339 timestamp, the current time is used when empty.")
+	flags.StringVar(&opts.policyFile, "policy-file", "", "YAML policy overriding the decision flags.")
+	flags.StringVar(&opts.previousPolicyFile, "previous-policy-file", "",
+		"YAML policy the verdicts are compared against, overriding the decision flags.")
+	flags.StringVarP(&opts.output, "output", "o", "table", "Output format: table, json or yaml.")
+	flags.BoolVar(&opts.all, "all", false, "Also report the PVs that are skipped under both policies.")
+	opts.decision.addFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if len(opts.snapshots) == 0 {
+		_, _ = fmt.Fprintln(stderr, "simulate requires --snapshot")
+		return 2
+	}
+	switch opts.output {
+	case "table", "json", "yaml":
+	default:
+		_, _ = fmt.Fprintf(stderr, "unsupported output %q, expected table, json or yaml\n", opts.output)
+		return 2
+	}
+
+	at := time.Now()
+	if opts.at != "" {
+		var err error
+		if at, err = time.Parse(time.RFC3339, opts.at); err != nil {
+			_, _ = fmt.Fprintf(stderr, "invalid --at: %v\n", err)
+			return 2
+		}
+	}
+
+	objects, err := loadSnapshots(opts.snapshots, os.Stdin)
+	if err == nil {
+		var report simulateReport
+		if report, err = simulate(context.Background(), objects, at, opts); err == nil {
+			err = writeSimulateReport(stdout, opts.output, report)
+		}
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "simulate failed: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// simulate assesses every PV of the snapshot under the policy, and the previous policy when set
+func simulate(ctx context.Context, objects []client.Object, at time.Time, opts simulateOptions) (simulateReport, error) {
+	current := opts.decision
+	if opts.policyFile != "" {
+		var err error
+		if current, err = opts.decision.withPolicyFile(opts.policyFile); err != nil {
+			return simulateReport{}, fmt.Errorf("loading --policy-file: %w", err)
+		}
+	}
+	decisions, err := assessSnapshot(ctx, objects, at, current)
+	if err != nil {
+		return simulateReport{}, err
+	}
+	var previous []controller.Decision
+	if opts.previousPolicyFile != "" {
+		previousOpts, err := opts.decision.withPolicyFile(opts.previousPolicyFile)
+		if err != nil {
+			return simulateReport{}, fmt.Errorf("loading --previous-policy-file: %w", err)
+		}
+		if previous, err = assessSnapshot(ctx, objects, at, previousOpts); err != nil {
+			return simulateReport{}, err
+		}
+	}
+
+	report := simulateReport{At: at.UTC(), PVs: []simulatedVerdict{}}
+	for i, decision := range decisions {
+		verdict := simulatedVerdict{Decision: decision}
+		if previous != nil {
+			verdict.Previous = &previous[i]
+		}
+		skipped := decision.Verdict == controller.VerdictSkip &&
+			(verdict.Previous == nil || verdict.Previous.Verdict == controller.VerdictSkip)
+		if skipped && !opts.all {
+			continue
+		}
+		if verdict.changed() {
+			report.Changed++
+		}
+		report.PVs = append(report.PVs, verdict)
+	}
+
+	return report, nil
+}
+
+// assessSnapshot assesses the PVs of the snapshot, sorted by name, with a fake client holding the snapshot
+func assessSnapshot(ctx context.Context, objects []client.Object, at time.Time,
+	opts decisionOptions) ([]controller.Decision, error) {
+	c := crFake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	r, err := opts.controller(c)
+	if err != nil {
+		return nil, err
+	}
+	r.Clock = clocktesting.NewFakePassiveClock(at)
+
+	var pvList corev1.PersistentVolumeList
+	if err := c.List(ctx, &pvList); err != nil {
+		return nil, err
+	}
+	sort.Slice(pvList.Items, func(i, j int) bool {
+		return pvList.Items[i].Name < pvList.Items[j].Name
+	})
+
+	decisions := make([]controller.Decision, 0, len(pvList.Items))
+	for _, pv := range pvList.Items {
+		decision, err := r.Assess(ctx, pv)
+		if err != nil {
+			return nil, fmt.Errorf("assessing PV %s: %w", pv.Name, err)
+		}
+		decisions = append(decisions, decision)
+	}
+	return decisions, nil
+}
+
+// loadSnapshots reads the objects of the given files and directories, later objects replace earlier ones
+func loadSnapshots(paths []string, stdin io.Reader) ([]client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	byKey := map[string]client.Object{}
+	var keys []string
+	add := func(obj runtime.Object) {
+		switch obj.(type) {
+		case *corev1.PersistentVolume, *corev1.PersistentVolumeClaim, *corev1.Node, *corev1.Namespace,
+			*storagev1.StorageClass, *storagev1.VolumeAttachment:
+		default:
+			return
+		}
+		o := obj.(client.Object)
+		o.SetResourceVersion("")
+		o.SetManagedFields(nil)
+		key := fmt.Sprintf("%T/%s/%s", o, o.GetNamespace(), o.GetName())
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = o
+	}
+
+	for _, path := range paths {
+		files, err := snapshotFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			var data []byte
+			if file == "-" {
+				data, err = io.ReadAll(stdin)
+			} else {
+				data, err = os.ReadFile(file)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if err := decodeObjects(decoder, data, add); err != nil {
+				return nil, fmt.Errorf("decoding %s: %w", file, err)
+			}
+		}
+	}
+
+	objects := make([]client.Object, 0, len(keys))
+	for _, key := range keys {
+		objects = append(objects, byKey[key])
+	}
+	return objects, nil
+}
+
+// snapshotFiles returns the YAML and JSON files of a directory, or the path itself when it is a file
+func snapshotFiles(path string) ([]string, error) {
+	if path == "-" {
+		return []string{path}, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(file string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, file)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// decodeObjects decodes the documents of a multi-document YAML or JSON stream, unwrapping lists
+func decodeObjects(decoder runtime.Decoder, data []byte, add func(runtime.Object)) error {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if runtime.IsNotRegisteredError(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		list, ok := obj.(*corev1.List)
+		if !ok {
+			add(obj)
+			continue
+		}
+		for _, item := range list.Items {
+			obj, _, err := decoder.Decode(item.Raw, nil, nil)
+			if runtime.IsNotRegisteredError(err) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			add(obj)
+		}
+	}
+}
+
+// writeSimulateReport prints the report in the given format
+func writeSimulateReport(out io.Writer, format string, report simulateReport) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case "yaml":
+		data, err := yaml.Marshal(report)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	}
+
+	compared := len(report.PVs) > 0 && report.PVs[0].Previous != nil
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	header := "PV\tSTORAGE CLASS\tNODE\tNODE STATUS\tVERDICT\tREASON"
+	if compared {
+		header += "\tPREVIOUS VERDICT\tPREVIOUS REASON"
+	}
+	_, _ = fmt.Fprintln(w, header)
+	for _, v := range report.PVs {
+		row := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s", v.PV, orDash(v.StorageClass), orDash(v.Node),
+			orDash(string(v.NodeStatus)), v.Verdict, v.Reason)
+		if compared {
+			row += fmt.Sprintf("\t%s\t%s", v.Previous.Verdict, v.Previous.Reason)
+		}
+		_, _ = fmt.Fprintln(w, row)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if !compared {
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(out, "\n%d verdicts changed at %s\n", report.Changed, report.At.Format(time.RFC3339))
+	for _, v := range report.PVs {
+		if v.changed() {
+			_, _ = fmt.Fprintf(out, "  %s: %s -> %s\n", v.PV, describeVerdict(*v.Previous), describeVerdict(v.Decision))
+		}
+	}
+	return nil
+}
+
+// describeVerdict returns the verdict and its reason
+func describeVerdict(d controller.Decision) string {
+	return fmt.Sprintf("%s (%s)", d.Verdict, d.Reason)
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/controller"
+)
+
+// testSnapshotList is a kubectl get -o yaml list of PVs
+const testSnapshotList = `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: PersistentVolume
+  metadata:
+    name: pv-orphan
+    resourceVersion: "42"
+    annotations:
+      localpvcleaner.io/orphaned-at: "2025-03-01T11:30:00Z"
+  spec:
+    storageClassName: topolvm
+    persistentVolumeReclaimPolicy: Retain
+    nodeAffinity:
+      required:
+        nodeSelectorTerms:
+        - matchExpressions:
+          - key: topology.topolvm.io/node
+            operator: In
+            values: [node-gone]
+  status:
+    phase: Bound
+- apiVersion: v1
+  kind: PersistentVolume
+  metadata:
+    name: pv-healthy
+  spec:
+    storageClassName: topolvm
+    persistentVolumeReclaimPolicy: Retain
+    nodeAffinity:
+      required:
+        nodeSelectorTerms:
+        - matchExpressions:
+          - key: topology.topolvm.io/node
+            operator: In
+            values: [node-01]
+  status:
+    phase: Bound
+- apiVersion: v1
+  kind: PersistentVolume
+  metadata:
+    name: pv-openebs
+  spec:
+    storageClassName: openebs
+    persistentVolumeReclaimPolicy: Retain
+    nodeAffinity:
+      required:
+        nodeSelectorTerms:
+        - matchExpressions:
+          - key: topology.topolvm.io/node
+            operator: In
+            values: [node-gone]
+  status:
+    phase: Bound
+`
+
+// testSnapshotDocs is a multi-document YAML of nodes and other objects
+const testSnapshotDocs = `apiVersion: v1
+kind: Node
+metadata:
+  name: node-01
+---
+apiVersion: storage.k8s.io/v1
+kind: StorageClass
+metadata:
+  name: topolvm
+provisioner: topolvm.io
+---
+apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: ignored
+`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "pvs.yaml", testSnapshotList)
+	writeFile(t, dir, "nodes.yml", testSnapshotDocs)
+	writeFile(t, dir, "README.md", "not a snapshot")
+
+	objects, err := loadSnapshots([]string{dir, "-"}, strings.NewReader(testSnapshotDocs))
+	require.NoError(t, err)
+	var names []string
+	for _, obj := range objects {
+		names = append(names, obj.GetName())
+		assert.Empty(t, obj.GetResourceVersion())
+	}
+	assert.Equal(t, []string{"node-01", "topolvm", "pv-orphan", "pv-healthy", "pv-openebs"}, names)
+}
+
+func TestSimulate(t *testing.T) {
+	dir := t.TempDir()
+	objects, err := loadSnapshots([]string{
+		writeFile(t, dir, "pvs.yaml", testSnapshotList),
+		writeFile(t, dir, "nodes.yaml", testSnapshotDocs),
+	}, nil)
+	require.NoError(t, err)
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	opts := simulateOptions{
+		policyFile:         writeFile(t, dir, "policy.yaml", "orphanRule: \"false\"\nstorageClassNames: [topolvm, openebs]\n"),
+		previousPolicyFile: writeFile(t, dir, "previous.yaml", "orphanRule: \"true\"\n"),
+		decision: decisionOptions{
+			nodeSelectorKeys:   []string{testNodeSelectorKey},
+			storageClassNames:  []string{"topolvm"},
+			reclaimPolicyNames: []string{"Retain"},
+		},
+	}
+
+	report, err := simulate(context.Background(), objects, at, opts)
+	require.NoError(t, err)
+	got := map[string]string{}
+	for _, v := range report.PVs {
+		got[v.PV] = describeVerdict(*v.Previous) + " -> " + describeVerdict(v.Decision)
+	}
+	assert.Equal(t, map[string]string{
+		"pv-healthy": "keep (node-exists) -> keep (node-exists)",
+		"pv-openebs": "skip (storage-class) -> keep (orphan-rule)",
+		"pv-orphan":  "delete (orphaned) -> keep (orphan-rule)",
+	}, got)
+	assert.Equal(t, 2, report.Changed)
+
+	var out bytes.Buffer
+	require.NoError(t, writeSimulateReport(&out, "table", report))
+	assert.Contains(t, out.String(), "2 verdicts changed at 2025-03-01T12:00:00Z\n"+
+		"  pv-openebs: skip (storage-class) -> keep (orphan-rule)\n"+
+		"  pv-orphan: delete (orphaned) -> keep (orphan-rule)\n")
+
+	// without a previous policy, skipped PVs are left out
+	opts.previousPolicyFile = ""
+	opts.policyFile = ""
+	report, err = simulate(context.Background(), objects, at.Add(time.Hour), opts)
+	require.NoError(t, err)
+	require.Len(t, report.PVs, 2)
+	assert.Equal(t, controller.VerdictKeep, report.PVs[0].Verdict)
+	assert.Equal(t, controller.VerdictDelete, report.PVs[1].Verdict)
+	assert.Nil(t, report.PVs[1].Previous)
+}
+
+func TestDecisionOptions_withPolicyFile(t *testing.T) {
+	dir := t.TempDir()
+	base := decisionOptions{storageClassNames: []string{"topolvm"}, releasedTTL: time.Hour}
+
+	got, err := base.withPolicyFile(writeFile(t, dir, "policy.yaml", "releasedTTL: 24h\nnamespaceOptIn: true\n"))
+	require.NoError(t, err)
+	assert.Equal(t, decisionOptions{storageClassNames: []string{"topolvm"}, releasedTTL: 24 * time.Hour,
+		namespaceOptIn: true}, got)
+
+	got, err = base.withPolicyFile(writeFile(t, dir, "windows.yaml",
+		"maintenanceWindows: [\"Mon-Fri 09:00-17:00 Europe/Berlin\", \"cron 0 22 * * Sat 6h\"]\n"))
+	require.NoError(t, err)
+	r, err := got.controller(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Mon-Fri 09:00-17:00 Europe/Berlin; cron 0 22 * * Sat 6h", r.MaintenanceWindows.String())
+
+	got.maintenanceWindows = []string{"Mon-Fri 9-17"}
+	_, err = got.controller(nil)
+	assert.ErrorContains(t, err, "invalid time")
+
+	_, err = base.withPolicyFile(writeFile(t, dir, "typo.yaml", "storageClasses: [topolvm]\n"))
+	assert.Error(t, err, "Expected unknown fields to be rejected")
+}
+
+func TestDecisionOptions_expressions(t *testing.T) {
+	dir := t.TempDir()
+	base := decisionOptions{nodeSelectorKeys: []string{testNodeSelectorKey}, reclaimPolicyNames: []string{"Retain"}}
+
+	opts, err := base.withPolicyFile(writeFile(t, dir, "policy.yaml",
+		"selector: pv.spec.storageClassName == \"topolvm\"\norphanRule: node == null\n"))
+	require.NoError(t, err)
+	r, err := opts.controller(nil)
+	require.NoError(t, err)
+	assert.Equal(t, `pv.spec.storageClassName == "topolvm"`, r.Selector.String())
+	assert.Equal(t, "node == null", r.OrphanRule.String())
+
+	opts, err = base.withPolicyFile(writeFile(t, dir, "invalid.yaml", "orphanRule: nodes == null\n"))
+	require.NoError(t, err)
+	_, err = opts.controller(nil)
+	assert.ErrorContains(t, err, "orphan-rule: invalid expression")
+	assert.ErrorContains(t, err, "undeclared reference to 'nodes'")
+}
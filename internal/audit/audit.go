@@ -0,0 +1,111 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit writes one structured record per destructive action of the controller
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Action is the kind of audited action
+type Action string
+
+const (
+	// ActionDelete is recorded when a PV got deleted
+	ActionDelete Action = "delete"
+	// ActionDryRun is recorded when a PV would have been deleted without the dry-run mode
+	ActionDryRun Action = "dry-run"
+	// ActionFinalizerStrip is recorded when the external-provisioner finalizer got stripped from a PV
+	ActionFinalizerStrip Action = "finalizer-strip"
+	// ActionReclaim is recorded when the reclaim policy of a Released PV got switched to Delete,
+	// for its provisioner to delete the backing volume and the PV
+	ActionReclaim Action = "reclaim"
+	// ActionGuardTrip is recorded when a guard blocked the deletion of a PV
+	ActionGuardTrip Action = "guard-trip"
+)
+
+// Record is a single audited action, serialized as one JSON object
+type Record struct {
+	Time         time.Time         `json:"time"`
+	Action       Action            `json:"action"`
+	PV           string            `json:"pv"`
+	UID          types.UID         `json:"uid"`
+	StorageClass string            `json:"storageClass"`
+	Claim        string            `json:"claim,omitempty"`
+	Node         string            `json:"node,omitempty"`
+	Cause        string            `json:"cause,omitempty"`
+	Message      string            `json:"message,omitempty"`
+	Evidence     map[string]string `json:"evidence,omitempty"`
+	Controller   string            `json:"controller"`
+}
+
+// Sink receives the audit records
+type Sink interface {
+	// Write persists a record, it must be safe for concurrent use
+	Write(ctx context.Context, record Record) error
+}
+
+// multiSink writes every record to all of its sinks
+type multiSink []Sink
+
+// Write implements Sink
+func (m multiSink) Write(ctx context.Context, record Record) error {
+	var errs []error
+	for _, sink := range m {
+		if err := sink.Write(ctx, record); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NewSink returns the sink described by the given specs, nil when there is none. A spec is either "stdout",
+// a "file:" prefixed path or an http(s) webhook URL.
+func NewSink(specs []string, webhookTimeout time.Duration) (Sink, error) {
+	var sinks multiSink
+	for _, spec := range specs {
+		switch {
+		case spec == "stdout":
+			sinks = append(sinks, NewStdoutSink())
+		case strings.HasPrefix(spec, "file:"):
+			sink, err := NewFileSink(strings.TrimPrefix(spec, "file:"))
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		case strings.HasPrefix(spec, "http://"), strings.HasPrefix(spec, "https://"):
+			sinks = append(sinks, NewWebhookSink(spec, webhookTimeout))
+		default:
+			return nil, fmt.Errorf("invalid audit sink %q, expected stdout, file:<path> or an http(s) URL", spec)
+		}
+	}
+
+	switch len(sinks) {
+	case 0:
+		return nil, nil
+	case 1:
+		return sinks[0], nil
+	default:
+		return sinks, nil
+	}
+}
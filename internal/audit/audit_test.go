@@ -0,0 +1,156 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecord(pv string) Record {
+	return Record{
+		Time:         time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		Action:       ActionDelete,
+		PV:           pv,
+		UID:          "uid-1",
+		StorageClass: "topolvm",
+		Claim:        "team-a/data",
+		Node:         "node-gone",
+		Cause:        "orphaned",
+		Evidence:     map[string]string{"nodeFound": "false"},
+		Controller:   "local-pv-cleaner-0",
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	dir := t.TempDir()
+
+	var tests = []struct {
+		name    string
+		specs   []string
+		wantNil bool
+		wantErr bool
+	}{
+		{name: "No sink", wantNil: true},
+		{name: "Stdout", specs: []string{"stdout"}},
+		{name: "File and webhook", specs: []string{"file:" + filepath.Join(dir, "audit.jsonl"), "https://audit.example.com"}},
+		{name: "Unknown sink", specs: []string{"syslog"}, wantErr: true},
+		{name: "Unwritable file", specs: []string{"file:" + filepath.Join(dir, "missing", "audit.jsonl")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink, err := NewSink(tt.specs, time.Second)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNil, sink == nil)
+		})
+	}
+}
+
+func TestFileSink_concurrentWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := NewFileSink(path)
+	require.NoError(t, err)
+
+	const records = 50
+	var wg sync.WaitGroup
+	for range records {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, sink.Write(context.Background(), newRecord("pv-1")))
+		}()
+	}
+	wg.Wait()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close() //nolint:errcheck
+	scanner := bufio.NewScanner(f)
+	lines := 0
+	for scanner.Scan() {
+		var record Record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record), "Expected every line to be a whole record")
+		assert.Equal(t, newRecord("pv-1"), record)
+		lines++
+	}
+	assert.Equal(t, records, lines)
+}
+
+func TestWebhookSink(t *testing.T) {
+	var received []Record
+	var mu sync.Mutex
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		var record Record
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&record))
+		mu.Lock()
+		received = append(received, record)
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, time.Second)
+	require.NoError(t, sink.Write(context.Background(), newRecord("pv-1")))
+	assert.Equal(t, []Record{newRecord("pv-1")}, received)
+
+	status = http.StatusInternalServerError
+	assert.Error(t, sink.Write(context.Background(), newRecord("pv-2")))
+}
+
+func TestWebhookSink_errorsHideTheURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	for _, url := range []string{server.URL + "/hooks/s3cr3t", "http://127.0.0.1:1/hooks/s3cr3t"} {
+		err := NewWebhookSink(url, time.Second).Write(context.Background(), newRecord("pv-1"))
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "s3cr3t")
+		assert.Contains(t, err.Error(), "127.0.0.1")
+	}
+}
+
+func TestMultiSink(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewSink([]string{"file:" + filepath.Join(dir, "a.jsonl"), "http://127.0.0.1:1"}, time.Second)
+	require.NoError(t, err)
+
+	assert.Error(t, sink.Write(context.Background(), newRecord("pv-1")), "Expected the webhook error to be returned")
+	data, err := os.ReadFile(filepath.Join(dir, "a.jsonl"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, data, "Expected the file sink to be written despite the webhook error")
+}
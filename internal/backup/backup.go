@@ -0,0 +1,169 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backup stores the manifests of PVs and their claims before the controller deletes them,
+// and prepares them to be recreated
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrNotFound is returned when a snapshot does not exist
+var ErrNotFound = errors.New("snapshot not found")
+
+// Snapshot holds the manifests of a PV and of its bound claim, as they were before the PV got deleted
+type Snapshot struct {
+	// TakenAt is when the snapshot was taken
+	TakenAt time.Time `json:"takenAt"`
+	// Cause is why the PV got deleted
+	Cause string `json:"cause,omitempty"`
+	// PersistentVolume is the deleted PV
+	PersistentVolume corev1.PersistentVolume `json:"persistentVolume"`
+	// PersistentVolumeClaim is the claim the PV was bound to, if it still existed
+	PersistentVolumeClaim *corev1.PersistentVolumeClaim `json:"persistentVolumeClaim,omitempty"`
+}
+
+// maxNamePVLength bounds the part of a snapshot name taken from the PV name, so that the name fits
+// a ConfigMap name and a file name once prefixed and suffixed
+const maxNamePVLength = 200
+
+// Name returns the name the snapshot is stored under.
+// Long PV names are truncated and suffixed with a hash of the full name.
+func (s *Snapshot) Name() string {
+	pvName := s.PersistentVolume.Name
+	if len(pvName) > maxNamePVLength {
+		hash := nameHash(pvName, 10)
+		// a truncated DNS subdomain must still end with an alphanumeric character
+		pvName = strings.TrimRight(pvName[:maxNamePVLength-len(hash)-1], ".-") + "-" + hash
+	}
+	return fmt.Sprintf("%s-%d", pvName, s.TakenAt.Unix())
+}
+
+// nameHash returns the first n hex characters of the SHA-256 hash of a name
+func nameHash(name string, n int) string {
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:])[:n]
+}
+
+// Entry describes a stored snapshot
+type Entry struct {
+	Name    string
+	PV      string
+	TakenAt time.Time
+}
+
+// Store persists snapshots
+type Store interface {
+	// Save stores the snapshot and prunes the snapshots past the retention limits
+	Save(ctx context.Context, snapshot *Snapshot) error
+	// Load returns the snapshot stored under the given name
+	Load(ctx context.Context, name string) (*Snapshot, error)
+	// List returns the stored snapshots, oldest first
+	List(ctx context.Context) ([]Entry, error)
+	// Delete removes the snapshot stored under the given name
+	Delete(ctx context.Context, name string) error
+}
+
+// Retention limits the stored snapshots, a zero limit is disabled
+type Retention struct {
+	// MaxCount is the maximum number of stored snapshots, the oldest ones are pruned first
+	MaxCount int
+	// MaxAge is how long the snapshots are kept
+	MaxAge time.Duration
+}
+
+// expired returns the entries past the retention limits, given the entries sorted oldest first
+func (r Retention) expired(entries []Entry, now time.Time) []Entry {
+	var expired []Entry
+	for i, entry := range entries {
+		tooOld := r.MaxAge > 0 && now.Sub(entry.TakenAt) > r.MaxAge
+		tooMany := r.MaxCount > 0 && len(entries)-i > r.MaxCount
+		if tooOld || tooMany {
+			expired = append(expired, entry)
+		}
+	}
+	return expired
+}
+
+// prune deletes the snapshots of the store past the retention limits
+func prune(ctx context.Context, store Store, retention Retention, now time.Time) error {
+	if retention.MaxCount <= 0 && retention.MaxAge <= 0 {
+		return nil
+	}
+
+	entries, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, entry := range retention.expired(entries, now) {
+		if err := store.Delete(ctx, entry.Name); err != nil && !errors.Is(err, ErrNotFound) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sortEntries sorts the entries oldest first
+func sortEntries(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].TakenAt.Equal(entries[j].TakenAt) {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].TakenAt.Before(entries[j].TakenAt)
+	})
+}
+
+// Latest returns the most recent snapshot entry of the given PV
+func Latest(ctx context.Context, store Store, pvName string) (Entry, error) {
+	entries, err := store.List(ctx)
+	if err != nil {
+		return Entry{}, err
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].PV == pvName {
+			return entries[i], nil
+		}
+	}
+	return Entry{}, fmt.Errorf("%w for PV %s", ErrNotFound, pvName)
+}
+
+// NewStore returns the store described by the spec, nil when the spec is empty.
+// The spec is either "configmap:<namespace>" or "dir:<path>".
+func NewStore(spec string, c client.Client, reader client.Reader, retention Retention) (Store, error) {
+	kind, target, _ := strings.Cut(spec, ":")
+	switch {
+	case spec == "":
+		return nil, nil
+	case kind == "configmap" && target != "":
+		return NewConfigMapStore(c, reader, target, retention), nil
+	case kind == "dir" && target != "":
+		return NewDirStore(target, retention)
+	default:
+		return nil, fmt.Errorf("invalid backup store %q, expected configmap:<namespace> or dir:<path>", spec)
+	}
+}
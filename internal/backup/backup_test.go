@@ -0,0 +1,152 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes/scheme"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testNow = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// newSnapshot returns a snapshot of a bound CSI PV taken at the given time
+func newSnapshot(pvName string, takenAt time.Time) *Snapshot {
+	return &Snapshot{
+		TakenAt: takenAt,
+		Cause:   "orphaned",
+		PersistentVolume: corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: pvName, UID: "pv-uid", ResourceVersion: "42",
+				Annotations: map[string]string{
+					"pv.kubernetes.io/provisioned-by": "topolvm.io",
+					"localpvcleaner.io/orphaned-at":   "2025-03-01T11:00:00Z",
+				},
+				Finalizers: []string{"kubernetes.io/pv-protection"},
+			},
+			Spec: corev1.PersistentVolumeSpec{
+				StorageClassName: "topolvm",
+				PersistentVolumeSource: corev1.PersistentVolumeSource{
+					CSI: &corev1.CSIPersistentVolumeSource{Driver: "topolvm.io", VolumeHandle: "handle-1"},
+				},
+				ClaimRef: &corev1.ObjectReference{
+					Namespace: "team-a", Name: "data", UID: "old-pvc-uid", ResourceVersion: "7",
+				},
+			},
+			Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+		},
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "data", UID: "old-pvc-uid", ResourceVersion: "8"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: pvName},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+		},
+	}
+}
+
+func TestSnapshot_Name(t *testing.T) {
+	long := strings.Repeat("a", 190) + "." + strings.Repeat("b", 62)
+	var tests = []struct {
+		name   string
+		pvName string
+		want   string
+	}{
+		{name: "Short PV name", pvName: "pv-1", want: "pv-1-1740830400"},
+		{name: "Long PV name", pvName: long, want: strings.Repeat("a", 189) + "-7c8e649a61-1740830400"},
+		{name: "Long PV name truncated at a dot", pvName: strings.Repeat("a", 188) + "." + strings.Repeat("b", 64),
+			want: strings.Repeat("a", 188) + "-21984a6b14-1740830400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := newSnapshot(tt.pvName, testNow).Name()
+			assert.Equal(t, tt.want, name)
+			assert.Empty(t, validation.IsDNS1123Subdomain(configMapPrefix+name))
+			assert.LessOrEqual(t, len(name+snapshotExt), 255, "Expected the name to fit a file name")
+		})
+	}
+}
+
+func TestRetention_expired(t *testing.T) {
+	entries := []Entry{
+		{Name: "a", TakenAt: testNow.Add(-72 * time.Hour)},
+		{Name: "b", TakenAt: testNow.Add(-3 * time.Hour)},
+		{Name: "c", TakenAt: testNow.Add(-2 * time.Hour)},
+		{Name: "d", TakenAt: testNow.Add(-1 * time.Hour)},
+	}
+
+	var tests = []struct {
+		name      string
+		retention Retention
+		want      []string
+	}{
+		{name: "No limit", retention: Retention{}},
+		{name: "Max count", retention: Retention{MaxCount: 2}, want: []string{"a", "b"}},
+		{name: "Max age", retention: Retention{MaxAge: 24 * time.Hour}, want: []string{"a"}},
+		{name: "Both", retention: Retention{MaxCount: 3, MaxAge: 150 * time.Minute}, want: []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, entry := range tt.retention.expired(entries, testNow) {
+				names = append(names, entry.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	c := crFake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+
+	var tests = []struct {
+		spec    string
+		want    any
+		wantErr bool
+	}{
+		{spec: ""},
+		{spec: "configmap:local-pv-cleaner", want: &ConfigMapStore{}},
+		{spec: "dir:" + filepath.Join(t.TempDir(), "backups"), want: &DirStore{}},
+		{spec: "configmap:", wantErr: true},
+		{spec: "s3://bucket", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			store, err := NewStore(tt.spec, c, c, Retention{})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want == nil {
+				assert.Nil(t, store)
+				return
+			}
+			assert.IsType(t, tt.want, store)
+		})
+	}
+}
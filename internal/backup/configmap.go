@@ -0,0 +1,152 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// BackupLabel marks the ConfigMaps holding a snapshot
+	BackupLabel = "localpvcleaner.io/backup"
+	// pvKey is the annotation holding the name of the PV of a snapshot ConfigMap, and the label holding the name
+	// or a hash of it when the name is too long for a label value
+	pvKey = "localpvcleaner.io/pv"
+	// takenAtAnnotation holds the RFC3339 time the snapshot of a ConfigMap was taken
+	takenAtAnnotation = "localpvcleaner.io/taken-at"
+	// configMapPrefix prefixes the snapshot names to build the ConfigMap names
+	configMapPrefix = "pv-backup-"
+	// snapshotKey is the ConfigMap data key holding the snapshot
+	snapshotKey = "snapshot.json"
+)
+
+// ConfigMapStore stores every snapshot in its own ConfigMap of a namespace
+type ConfigMapStore struct {
+	client    client.Client
+	reader    client.Reader
+	namespace string
+	retention Retention
+}
+
+// NewConfigMapStore returns a ConfigMapStore writing through the client and reading through the reader.
+// Passing an uncached reader avoids caching every ConfigMap of the cluster.
+func NewConfigMapStore(c client.Client, reader client.Reader, namespace string, retention Retention) *ConfigMapStore {
+	return &ConfigMapStore{client: c, reader: reader, namespace: namespace, retention: retention}
+}
+
+// Save implements Store
+func (s *ConfigMapStore) Save(ctx context.Context, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapPrefix + snapshot.Name(),
+			Namespace: s.namespace,
+			Labels:    map[string]string{BackupLabel: "true", pvKey: pvLabelValue(snapshot.PersistentVolume.Name)},
+			Annotations: map[string]string{
+				pvKey:             snapshot.PersistentVolume.Name,
+				takenAtAnnotation: snapshot.TakenAt.UTC().Format(time.RFC3339),
+			},
+		},
+		Data: map[string]string{snapshotKey: string(data)},
+	}
+	if err := s.client.Create(ctx, cm); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("storing snapshot %s: %w", snapshot.Name(), err)
+	}
+
+	return prune(ctx, s, s.retention, snapshot.TakenAt)
+}
+
+// pvLabelValue returns the pvKey label value of a PV, its name when it fits a label value or a hash of it
+func pvLabelValue(pvName string) string {
+	if len(validation.IsValidLabelValue(pvName)) == 0 {
+		return pvName
+	}
+	return "sha256-" + nameHash(pvName, validation.LabelValueMaxLength-len("sha256-"))
+}
+
+// Load implements Store
+func (s *ConfigMapStore) Load(ctx context.Context, name string) (*Snapshot, error) {
+	var cm corev1.ConfigMap
+	err := s.reader.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: configMapPrefix + name}, &cm)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal([]byte(cm.Data[snapshotKey]), &snapshot); err != nil {
+		return nil, fmt.Errorf("decoding snapshot %s: %w", name, err)
+	}
+	return &snapshot, nil
+}
+
+// List implements Store
+func (s *ConfigMapStore) List(ctx context.Context) ([]Entry, error) {
+	var list metav1.PartialObjectMetadataList
+	list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMapList"))
+	if err := s.reader.List(ctx, &list, client.InNamespace(s.namespace),
+		client.MatchingLabels{BackupLabel: "true"}); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(list.Items))
+	for _, cm := range list.Items {
+		takenAt, err := time.Parse(time.RFC3339, cm.Annotations[takenAtAnnotation])
+		if err != nil {
+			takenAt = cm.CreationTimestamp.Time
+		}
+		// the label can be set on any ConfigMap, the ones not named like a snapshot are not ours
+		name, ok := strings.CutPrefix(cm.Name, configMapPrefix)
+		if !ok || name == "" {
+			continue
+		}
+		entries = append(entries, Entry{
+			Name:    name,
+			PV:      cm.Annotations[pvKey],
+			TakenAt: takenAt,
+		})
+	}
+	sortEntries(entries)
+
+	return entries, nil
+}
+
+// Delete implements Store
+func (s *ConfigMapStore) Delete(ctx context.Context, name string) error {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: s.namespace, Name: configMapPrefix + name}}
+	err := s.client.Delete(ctx, cm)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// snapshotExt is the file extension of the snapshots of a DirStore
+const snapshotExt = ".json"
+
+// DirStore stores every snapshot as a JSON file of a local directory
+type DirStore struct {
+	// mu serializes the saves with their pruning
+	mu        sync.Mutex
+	dir       string
+	retention Retention
+}
+
+// NewDirStore returns a DirStore writing into dir, which is created if needed
+func NewDirStore(dir string, retention Retention) (*DirStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating backup directory: %w", err)
+	}
+	return &DirStore{dir: dir, retention: retention}, nil
+}
+
+// path returns the file of the named snapshot
+func (s *DirStore) path(name string) string {
+	return filepath.Join(s.dir, name+snapshotExt)
+}
+
+// Save implements Store
+func (s *DirStore) Save(ctx context.Context, snapshot *Snapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// write to a temporary file first, so that a crash never leaves a truncated snapshot behind
+	tmp, err := os.CreateTemp(s.dir, ".snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path(snapshot.Name())); err != nil {
+		return fmt.Errorf("storing snapshot %s: %w", snapshot.Name(), err)
+	}
+
+	return prune(ctx, s, s.retention, snapshot.TakenAt)
+}
+
+// Load implements Store
+func (s *DirStore) Load(_ context.Context, name string) (*Snapshot, error) {
+	data, err := os.ReadFile(s.path(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("decoding snapshot %s: %w", name, err)
+	}
+	return &snapshot, nil
+}
+
+// List implements Store
+func (s *DirStore) List(ctx context.Context) ([]Entry, error) {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, file := range files {
+		name, ok := strings.CutSuffix(file.Name(), snapshotExt)
+		if !ok || file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		snapshot, err := s.Load(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, Entry{Name: name, PV: snapshot.PersistentVolume.Name, TakenAt: snapshot.TakenAt})
+	}
+	sortEntries(entries)
+
+	return entries, nil
+}
+
+// Delete implements Store
+func (s *DirStore) Delete(_ context.Context, name string) error {
+	err := os.Remove(s.path(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return err
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// trackingAnnotations are the annotations the controller sets to track a PV, the ones set by the users are kept
+var trackingAnnotations = []string{"localpvcleaner.io/released-at", "localpvcleaner.io/orphaned-at"}
+
+// resetObjectMeta drops the server populated metadata so that the object can be created again
+func resetObjectMeta(meta *metav1.ObjectMeta) {
+	meta.UID = ""
+	meta.ResourceVersion = ""
+	meta.Generation = 0
+	meta.CreationTimestamp = metav1.Time{}
+	meta.DeletionTimestamp = nil
+	meta.DeletionGracePeriodSeconds = nil
+	meta.ManagedFields = nil
+	meta.OwnerReferences = nil
+}
+
+// RestorePV returns the PV of the snapshot ready to be created again.
+// The claimRef is bound to the UID of the live claim when it exists, otherwise only its namespace and name are kept
+// so that the PV binds to the claim once it is created again.
+func RestorePV(snapshot *Snapshot, liveClaim *corev1.PersistentVolumeClaim) *corev1.PersistentVolume {
+	pv := snapshot.PersistentVolume.DeepCopy()
+	resetObjectMeta(&pv.ObjectMeta)
+	pv.Finalizers = nil
+	pv.Status = corev1.PersistentVolumeStatus{}
+	// the tracking annotations of the controller would make it act on the restored PV at once
+	for _, key := range trackingAnnotations {
+		delete(pv.Annotations, key)
+	}
+
+	if claim := pv.Spec.ClaimRef; claim != nil {
+		claim.ResourceVersion = ""
+		claim.UID = ""
+		if liveClaim != nil && liveClaim.Namespace == claim.Namespace && liveClaim.Name == claim.Name {
+			claim.UID = liveClaim.UID
+		}
+	}
+
+	return pv
+}
+
+// RestorePVC returns the claim of the snapshot ready to be created again and bound to the restored PV,
+// nil when the snapshot holds no claim
+func RestorePVC(snapshot *Snapshot) *corev1.PersistentVolumeClaim {
+	if snapshot.PersistentVolumeClaim == nil {
+		return nil
+	}
+
+	pvc := snapshot.PersistentVolumeClaim.DeepCopy()
+	resetObjectMeta(&pvc.ObjectMeta)
+	pvc.Finalizers = nil
+	pvc.Status = corev1.PersistentVolumeClaimStatus{}
+	pvc.Spec.VolumeName = snapshot.PersistentVolume.Name
+
+	return pvc
+}
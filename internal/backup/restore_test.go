@@ -0,0 +1,87 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRestorePV(t *testing.T) {
+	var tests = []struct {
+		name      string
+		liveClaim *corev1.PersistentVolumeClaim
+		wantUID   types.UID
+	}{
+		{name: "Claim is gone"},
+		{
+			name: "Claim still exists",
+			liveClaim: &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "data", UID: "live-pvc-uid"},
+			},
+			wantUID: "live-pvc-uid",
+		},
+		{
+			name: "Another claim",
+			liveClaim: &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "data", UID: "other-pvc-uid"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot := newSnapshot("pv-1", testNow)
+			snapshot.PersistentVolume.Annotations["localpvcleaner.io/released-at"] = "2025-03-01T10:00:00Z"
+			snapshot.PersistentVolume.Annotations["localpvcleaner.io/retain-until"] = "2025-04-01T00:00:00Z"
+			pv := RestorePV(snapshot, tt.liveClaim)
+
+			assert.Empty(t, pv.UID)
+			assert.Empty(t, pv.ResourceVersion)
+			assert.Empty(t, pv.Finalizers)
+			assert.Empty(t, pv.Status.Phase)
+			// only the tracking annotations are dropped
+			assert.Equal(t, map[string]string{
+				"pv.kubernetes.io/provisioned-by": "topolvm.io",
+				"localpvcleaner.io/retain-until":  "2025-04-01T00:00:00Z",
+			}, pv.Annotations)
+			assert.Equal(t, "handle-1", pv.Spec.CSI.VolumeHandle)
+			assert.Equal(t, "team-a", pv.Spec.ClaimRef.Namespace)
+			assert.Equal(t, "data", pv.Spec.ClaimRef.Name)
+			assert.Equal(t, tt.wantUID, pv.Spec.ClaimRef.UID)
+			assert.Empty(t, pv.Spec.ClaimRef.ResourceVersion)
+
+			assert.Equal(t, types.UID("pv-uid"), snapshot.PersistentVolume.UID, "Expected the snapshot to be left alone")
+		})
+	}
+}
+
+func TestRestorePVC(t *testing.T) {
+	pvc := RestorePVC(newSnapshot("pv-1", testNow))
+	assert.Empty(t, pvc.UID)
+	assert.Empty(t, pvc.ResourceVersion)
+	assert.Empty(t, pvc.Status.Phase)
+	assert.Equal(t, "pv-1", pvc.Spec.VolumeName)
+
+	snapshot := newSnapshot("pv-1", testNow)
+	snapshot.PersistentVolumeClaim = nil
+	assert.Nil(t, RestorePVC(snapshot))
+}
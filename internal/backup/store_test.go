@@ -0,0 +1,129 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestStores(t *testing.T) {
+	newStores := map[string]func(t *testing.T, retention Retention) Store{
+		"ConfigMap": func(t *testing.T, retention Retention) Store {
+			c := crFake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+			return NewConfigMapStore(c, c, "local-pv-cleaner", retention)
+		},
+		"Dir": func(t *testing.T, retention Retention) Store {
+			store, err := NewDirStore(t.TempDir(), retention)
+			require.NoError(t, err)
+			return store
+		},
+	}
+
+	for name, newStore := range newStores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newStore(t, Retention{MaxCount: 2, MaxAge: 24 * time.Hour})
+
+			old := newSnapshot("pv-old", testNow.Add(-48*time.Hour))
+			require.NoError(t, store.Save(ctx, old))
+			for i, pv := range []string{"pv-1", "pv-2", "pv-1"} {
+				require.NoError(t, store.Save(ctx, newSnapshot(pv, testNow.Add(time.Duration(i)*time.Minute))))
+			}
+
+			entries, err := store.List(ctx)
+			require.NoError(t, err)
+			require.Len(t, entries, 2, "Expected the retention to prune the old and the oldest snapshots")
+			assert.Equal(t, "pv-2", entries[0].PV)
+			assert.Equal(t, "pv-1", entries[1].PV)
+			assert.True(t, entries[1].TakenAt.Equal(testNow.Add(2*time.Minute)))
+
+			latest, err := Latest(ctx, store, "pv-1")
+			require.NoError(t, err)
+			assert.Equal(t, entries[1], latest)
+			_, err = Latest(ctx, store, "pv-old")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			snapshot, err := store.Load(ctx, latest.Name)
+			require.NoError(t, err)
+			want := newSnapshot("pv-1", testNow.Add(2*time.Minute))
+			assert.True(t, want.TakenAt.Equal(snapshot.TakenAt))
+			assert.Equal(t, want.PersistentVolume.Spec, snapshot.PersistentVolume.Spec)
+			assert.Equal(t, want.PersistentVolumeClaim.Name, snapshot.PersistentVolumeClaim.Name)
+
+			require.NoError(t, store.Delete(ctx, latest.Name))
+			_, err = store.Load(ctx, latest.Name)
+			assert.ErrorIs(t, err, ErrNotFound)
+			assert.ErrorIs(t, store.Delete(ctx, latest.Name), ErrNotFound)
+
+			// a PV name of the maximum length is stored under a bounded name
+			long := strings.Repeat("a", 253)
+			require.NoError(t, store.Save(ctx, newSnapshot(long, testNow.Add(3*time.Minute))))
+			latest, err = Latest(ctx, store, long)
+			require.NoError(t, err)
+			snapshot, err = store.Load(ctx, latest.Name)
+			require.NoError(t, err)
+			assert.Equal(t, long, snapshot.PersistentVolume.Name)
+		})
+	}
+}
+
+func TestConfigMapStore_List_foreignConfigMaps(t *testing.T) {
+	ctx := context.Background()
+	foreign := []client.Object{
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "local-pv-cleaner", Name: "cm",
+			Labels: map[string]string{BackupLabel: "true"}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "local-pv-cleaner", Name: "settings-of-a-team",
+			Labels: map[string]string{BackupLabel: "true"}}},
+	}
+	c := crFake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(foreign...).Build()
+	store := NewConfigMapStore(c, c, "local-pv-cleaner", Retention{})
+	require.NoError(t, store.Save(ctx, newSnapshot("pv-1", testNow)))
+
+	entries, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "Expected the ConfigMaps not named like a snapshot to be skipped")
+	assert.Equal(t, "pv-1", entries[0].PV)
+}
+
+func TestConfigMapStore_Save_labels(t *testing.T) {
+	ctx := context.Background()
+	c := crFake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	store := NewConfigMapStore(c, c, "local-pv-cleaner", Retention{})
+	long := strings.Repeat("a", 253)
+	require.NoError(t, store.Save(ctx, newSnapshot("pv-1", testNow)))
+	require.NoError(t, store.Save(ctx, newSnapshot(long, testNow)))
+
+	for pvName, want := range map[string]string{"pv-1": "pv-1", long: pvLabelValue(long)} {
+		var list corev1.ConfigMapList
+		require.NoError(t, c.List(ctx, &list, client.MatchingLabels{pvKey: want}))
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, pvName, list.Items[0].Annotations[pvKey])
+		assert.Empty(t, validation.IsValidLabelValue(want))
+	}
+}
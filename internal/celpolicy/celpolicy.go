@@ -0,0 +1,266 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package celpolicy compiles and evaluates the CEL expressions selecting PVs and refining the orphan rule
+package celpolicy
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apiservercel "k8s.io/apiserver/pkg/cel"
+	"k8s.io/apiserver/pkg/cel/library"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// DefaultCostLimit bounds the runtime cost of a single evaluation, like the per-call limit of the API server
+const DefaultCostLimit uint64 = 1000000
+
+// Names of the variables the expressions are evaluated over
+const (
+	// VarPV is the PersistentVolume, with its metadata, spec and status
+	VarPV = "pv"
+	// VarPVC is the metadata of the claim of the PV, null when the PV has no claim or the claim is gone
+	VarPVC = "pvc"
+	// VarNamespace is the metadata of the claim namespace, null when there is no claim or the namespace is gone.
+	// It is not called namespace, which is reserved in CEL.
+	VarNamespace = "ns"
+	// VarNode is the metadata of the node of the PV, null when the node is missing
+	VarNode = "node"
+	// VarRecorded holds what the controller recorded about the PV: orphanedAt, releasedAt and nodeLabels, the labels
+	// last seen on a node that is gone. The fields it does not know are absent.
+	VarRecorded = "recorded"
+	// VarNow is the time of the evaluation
+	VarNow = "now"
+)
+
+// variableSchemas are the schemas of the object variables
+type variableSchemas struct {
+	pv       objectSchema
+	metadata objectSchema
+	recorded objectSchema
+}
+
+// schemas returns the schemas of the object variables, derived from the Go types of the objects
+var schemas = sync.OnceValue(func() variableSchemas {
+	metadata := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type:       []string{"object"},
+		Properties: map[string]spec.Schema{"metadata": *schemaOf(reflect.TypeOf(metav1.ObjectMeta{}))},
+		Nullable:   true,
+	}}
+	recorded := &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: []string{"object"},
+		Properties: map[string]spec.Schema{
+			"orphanedAt": *spec.DateTimeProperty(),
+			"releasedAt": *spec.DateTimeProperty(),
+			"nodeLabels": *spec.MapProperty(spec.StringProperty()),
+		},
+	}}
+
+	return variableSchemas{
+		pv:       newObjectSchema("localpvcleaner.PersistentVolume", schemaOf(reflect.TypeOf(corev1.PersistentVolume{}))),
+		metadata: newObjectSchema("localpvcleaner.ObjectMetadata", metadata),
+		recorded: newObjectSchema("localpvcleaner.Recorded", recorded),
+	}
+})
+
+// env returns the CEL environment declaring the typed variables and the Kubernetes extension libraries
+var env = sync.OnceValues(func() (*cel.Env, error) {
+	base, err := cel.NewEnv(
+		library.Quantity(),
+		library.Lists(),
+		library.Regex(),
+		library.URLs(),
+		cel.CrossTypeNumericComparisons(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s := schemas()
+	provider := apiservercel.NewDeclTypeProvider(s.pv.declType, s.metadata.declType, s.recorded.declType)
+	// fields named like a CEL keyword, such as metadata.namespace, are selected by their name
+	provider.SetRecognizeKeywordAsFieldName(true)
+	opts, err := provider.EnvOptions(base.CELTypeProvider())
+	if err != nil {
+		return nil, err
+	}
+	// the objects that may be missing are typed objects too, which compare to null
+	return base.Extend(append(opts,
+		cel.Variable(VarPV, s.pv.declType.CelType()),
+		cel.Variable(VarPVC, s.metadata.declType.CelType()),
+		cel.Variable(VarNamespace, s.metadata.declType.CelType()),
+		cel.Variable(VarNode, s.metadata.declType.CelType()),
+		cel.Variable(VarRecorded, s.recorded.declType.CelType()),
+		cel.Variable(VarNow, cel.TimestampType),
+	)...)
+})
+
+// Expression is a compiled and type-checked boolean CEL expression
+type Expression struct {
+	name    string
+	source  string
+	program cel.Program
+}
+
+// Compile parses and type-checks the source of the named expression, which must evaluate to a bool.
+// Every evaluation is aborted once its cost exceeds the given limit, DefaultCostLimit is used when zero.
+func Compile(name, source string, costLimit uint64) (*Expression, error) {
+	e, err := env()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	ast, issues := e.Compile(source)
+	if issues.Err() != nil {
+		return nil, fmt.Errorf("%s: invalid expression %q:\n%w", name, source, issues.Err())
+	}
+	if out := ast.OutputType(); !out.IsExactType(types.BoolType) && !out.IsExactType(types.DynType) {
+		return nil, fmt.Errorf("%s: expression %q must evaluate to a bool, not %s", name, source, out)
+	}
+
+	if costLimit == 0 {
+		costLimit = DefaultCostLimit
+	}
+	program, err := e.Program(ast, cel.CostLimit(costLimit), cel.InterruptCheckFrequency(100))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return &Expression{name: name, source: source, program: program}, nil
+}
+
+// String returns the source of the expression
+func (x *Expression) String() string {
+	return x.source
+}
+
+// Variables are the values an expression is evaluated over
+type Variables struct {
+	PV corev1.PersistentVolume
+	// Claim, Namespace and Node are nil when they do not exist
+	Claim     *metav1.ObjectMeta
+	Namespace *metav1.ObjectMeta
+	Node      *metav1.ObjectMeta
+	// OrphanedAt and ReleasedAt are zero when they are unknown
+	OrphanedAt time.Time
+	ReleasedAt time.Time
+	// NodeLabels are the labels last seen on the node of the PV once it is gone, nil when they are unknown
+	NodeLabels map[string]string
+	Now        time.Time
+}
+
+// Eval evaluates the expression over the given variables
+func (x *Expression) Eval(vars Variables) (bool, error) {
+	activation, err := vars.activation()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", x.name, err)
+	}
+
+	out, _, err := x.program.Eval(activation)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", x.name, err)
+	}
+	matched, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("%s: expression evaluated to %s, not a bool", x.name, out.Type().TypeName())
+	}
+	return matched, nil
+}
+
+// activation converts the variables to the values CEL understands
+func (v Variables) activation() (map[string]any, error) {
+	pv, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&v.PV)
+	if err != nil {
+		return nil, err
+	}
+	withEmptyMetadata(pv)
+	objects := map[string]*metav1.ObjectMeta{VarPVC: v.Claim, VarNamespace: v.Namespace, VarNode: v.Node}
+
+	s := schemas()
+	activation := map[string]any{
+		VarPV:       s.pv.value(pv),
+		VarRecorded: s.recorded.value(v.recorded()),
+		VarNow:      v.Now,
+	}
+	for name, meta := range objects {
+		object, err := metadataObject(meta)
+		if err != nil {
+			return nil, fmt.Errorf("converting %s: %w", name, err)
+		}
+		if object == nil {
+			activation[name] = types.NullValue
+			continue
+		}
+		activation[name] = s.metadata.value(object)
+	}
+
+	return activation, nil
+}
+
+// recorded returns the fields of the recorded variable the controller knows
+func (v Variables) recorded() map[string]any {
+	recorded := map[string]any{}
+	if !v.OrphanedAt.IsZero() {
+		recorded["orphanedAt"] = v.OrphanedAt.UTC().Format(time.RFC3339Nano)
+	}
+	if !v.ReleasedAt.IsZero() {
+		recorded["releasedAt"] = v.ReleasedAt.UTC().Format(time.RFC3339Nano)
+	}
+	if v.NodeLabels != nil {
+		labels := make(map[string]any, len(v.NodeLabels))
+		for key, value := range v.NodeLabels {
+			labels[key] = value
+		}
+		recorded["nodeLabels"] = labels
+	}
+	return recorded
+}
+
+// metadataObject returns an object holding only the given metadata, or nil
+func metadataObject(meta *metav1.ObjectMeta) (map[string]any, error) {
+	if meta == nil {
+		return nil, nil
+	}
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(meta)
+	if err != nil {
+		return nil, err
+	}
+	object = map[string]any{"metadata": object}
+	withEmptyMetadata(object)
+	return object, nil
+}
+
+// withEmptyMetadata makes sure the labels and annotations of an object exist, so expressions can index them
+// without checking their presence first
+func withEmptyMetadata(object map[string]any) {
+	meta, _ := object["metadata"].(map[string]any)
+	if meta == nil {
+		meta = map[string]any{}
+		object["metadata"] = meta
+	}
+	for _, key := range []string{"labels", "annotations"} {
+		if _, ok := meta[key]; !ok {
+			meta[key] = map[string]any{}
+		}
+	}
+}
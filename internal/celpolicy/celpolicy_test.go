@@ -0,0 +1,147 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package celpolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCompile(t *testing.T) {
+	var tests = []struct {
+		name    string
+		source  string
+		wantErr string
+	}{
+		{name: "Valid expression", source: `pv.spec.storageClassName == "topolvm"`},
+		{name: "Dynamic result", source: `dyn(pv.metadata.labels["managed"])`},
+		{name: "Unknown field", source: `pv.spec.capcity.storage == "1Gi"`, wantErr: "undefined field 'capcity'"},
+		{name: "Mistyped field", source: `pv.status.phase == 1`, wantErr: "no matching overload"},
+		{name: "Not a bool field", source: `pv.metadata.labels["managed"]`, wantErr: "must evaluate to a bool, not string"},
+		{name: "Syntax error", source: `pv.spec.storageClassName ==`, wantErr: "invalid expression"},
+		{name: "Undeclared variable", source: `volume.spec.storageClassName == "topolvm"`,
+			wantErr: "undeclared reference to 'volume'"},
+		{name: "Not a bool", source: `now - duration("1h")`, wantErr: "must evaluate to a bool, not google.protobuf.Timestamp"},
+		{name: "Unknown function", source: `quantity(pv.spec.capacity.storage).isHuge()`, wantErr: "isHuge"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Compile("selector", tt.source, 0)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "selector: ")
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.source, expr.String())
+		})
+	}
+}
+
+func TestExpression_Eval(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	full := Variables{
+		PV: corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Labels: map[string]string{"tier": "batch"},
+				CreationTimestamp: metav1.NewTime(now.Add(-48 * time.Hour))},
+			Spec: corev1.PersistentVolumeSpec{
+				StorageClassName: "topolvm",
+				Capacity:         corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("200Gi")},
+				ClaimRef:         &corev1.ObjectReference{Namespace: "team-a", Name: "data"},
+			},
+			Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeReleased},
+		},
+		Claim:      &metav1.ObjectMeta{Namespace: "team-a", Name: "data", Annotations: map[string]string{"owner": "me"}},
+		Namespace:  &metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"tier": "batch"}},
+		Node:       &metav1.ObjectMeta{Name: "node-01", Labels: map[string]string{"lifecycle": "spot"}},
+		OrphanedAt: now.Add(-7 * time.Hour),
+		ReleasedAt: now.Add(-time.Hour),
+		NodeLabels: map[string]string{"lifecycle": "spot"},
+		Now:        now,
+	}
+	bare := Variables{
+		PV:  corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv-1"}},
+		Now: now,
+	}
+
+	// every variable of the schema, and the paths expressions rely on
+	var tests = []struct {
+		name   string
+		source string
+		vars   Variables
+		want   bool
+	}{
+		{name: "PV metadata", source: `pv.metadata.name == "pv-1" && pv.metadata.labels.tier == "batch"`, vars: full, want: true},
+		{name: "PV spec", source: `pv.spec.storageClassName == "topolvm" && pv.spec.claimRef.namespace == "team-a"`,
+			vars: full, want: true},
+		{name: "PV status", source: `pv.status.phase == "Released"`, vars: full, want: true},
+		{name: "PV capacity", source: `quantity(pv.spec.capacity.storage).isGreaterThan(quantity("100Gi"))`,
+			vars: full, want: true},
+		{name: "PVC metadata", source: `pvc.metadata.namespace == "team-a" && pvc.metadata.annotations.owner == "me"`,
+			vars: full, want: true},
+		{name: "Namespace metadata", source: `ns.metadata.labels["tier"] == "batch"`, vars: full, want: true},
+		{name: "Node metadata", source: `node.metadata.labels["lifecycle"] == "spot"`, vars: full, want: true},
+		{name: "Recorded orphan time", source: `now - recorded.orphanedAt > duration("6h")`, vars: full, want: true},
+		{name: "Recorded release time", source: `now - recorded.releasedAt < duration("6h")`, vars: full, want: true},
+		{name: "Missing objects are null", source: `pvc == null && ns == null && node == null`,
+			vars: bare, want: true},
+		{name: "Recorded node labels", source: `recorded.nodeLabels["lifecycle"] == "spot"`, vars: full, want: true},
+		{name: "Unknown records are absent",
+			source: `!has(recorded.orphanedAt) && !has(recorded.releasedAt) && !has(recorded.nodeLabels)`,
+			vars:   bare, want: true},
+		{name: "PV timestamps", source: `pv.metadata.creationTimestamp < now`, vars: full, want: true},
+		{name: "Labels and annotations always exist", source: `!("tier" in pv.metadata.labels) && pv.metadata.annotations.size() == 0`,
+			vars: bare, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Compile("selector", tt.source, 0)
+			require.NoError(t, err)
+			got, err := expr.Eval(tt.vars)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExpression_Eval_errors(t *testing.T) {
+	vars := Variables{PV: corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv-1"}}}
+
+	expr, err := Compile("orphanRule", `node.metadata.name == "node-01"`, 0)
+	require.NoError(t, err)
+	_, err = expr.Eval(vars)
+	assert.ErrorContains(t, err, "orphanRule: ")
+
+	expr, err = Compile("selector", `dyn(pv.metadata.name)`, 0)
+	require.NoError(t, err)
+	_, err = expr.Eval(vars)
+	assert.ErrorContains(t, err, "not a bool")
+
+	expr, err = Compile("selector", `[1, 2, 3, 4, 5].all(x, [1, 2, 3, 4, 5].all(y, x + y > 0))`, 10)
+	require.NoError(t, err)
+	_, err = expr.Eval(vars)
+	assert.ErrorContains(t, err, "cost limit exceeded")
+}
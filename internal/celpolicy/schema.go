@@ -0,0 +1,137 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package celpolicy
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/google/cel-go/common/types/ref"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	apiservercel "k8s.io/apiserver/pkg/cel"
+	"k8s.io/apiserver/pkg/cel/common"
+	"k8s.io/apiserver/pkg/cel/openapi"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// Go types whose JSON form differs from their structure
+var (
+	timeType        = reflect.TypeOf(metav1.Time{})
+	microTimeType   = reflect.TypeOf(metav1.MicroTime{})
+	quantityType    = reflect.TypeOf(resource.Quantity{})
+	intOrStringType = reflect.TypeOf(intstr.IntOrString{})
+	fieldsV1Type    = reflect.TypeOf(metav1.FieldsV1{})
+)
+
+// objectSchema is the OpenAPI schema of a variable, with the CEL type declared for it
+type objectSchema struct {
+	schema   *openapi.Schema
+	declType *apiservercel.DeclType
+}
+
+// newObjectSchema returns the schema of a variable from its OpenAPI schema, naming its CEL type
+func newObjectSchema(name string, s *spec.Schema) objectSchema {
+	schema := &openapi.Schema{Schema: s}
+	return objectSchema{
+		schema:   schema,
+		declType: common.SchemaDeclType(schema, false).MaybeAssignTypeName(name),
+	}
+}
+
+// value converts an unstructured object to a CEL value of the schema, null when nil
+func (o objectSchema) value(unstructured any) ref.Val {
+	return common.UnstructuredToVal(unstructured, o.schema)
+}
+
+// schemaOf derives the OpenAPI schema of the JSON form of a Go type, like the one the API server publishes for
+// the built-in types. Every value may be null, since the optional fields are null in the unstructured form.
+func schemaOf(t reflect.Type) *spec.Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var s *spec.Schema
+	switch {
+	case t == timeType || t == microTimeType:
+		s = spec.DateTimeProperty()
+	case t == quantityType:
+		s = spec.StringProperty()
+	case t == intOrStringType:
+		s = &spec.Schema{VendorExtensible: spec.VendorExtensible{
+			Extensions: spec.Extensions{"x-kubernetes-int-or-string": true},
+		}}
+	case t == fieldsV1Type:
+		// managed fields are not exposed
+		return nil
+	case t.Kind() == reflect.Struct:
+		s = &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}, Properties: map[string]spec.Schema{}}}
+		addProperties(s, t)
+	case t.Kind() == reflect.Map:
+		items := schemaOf(t.Elem())
+		if items == nil {
+			return nil
+		}
+		s = spec.MapProperty(items)
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		s = &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string"}, Format: "byte"}}
+	case t.Kind() == reflect.Slice:
+		items := schemaOf(t.Elem())
+		if items == nil {
+			return nil
+		}
+		s = spec.ArrayProperty(items)
+	case t.Kind() == reflect.String:
+		s = spec.StringProperty()
+	case t.Kind() == reflect.Bool:
+		s = spec.BoolProperty()
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		s = spec.Int64Property()
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		s = spec.Float64Property()
+	default:
+		return nil
+	}
+	s.Nullable = true
+
+	return s
+}
+
+// addProperties adds the JSON fields of a struct to the properties of its schema, including the inlined ones
+func addProperties(s *spec.Schema, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" && (field.Anonymous || strings.Contains(opts, "inline")) {
+			addProperties(s, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if property := schemaOf(field.Type); property != nil {
+			s.Properties[name] = *property
+		}
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cloudevents emits the cleanup lifecycle as CloudEvents 1.0 over HTTP
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"time"
+)
+
+// SpecVersion is the implemented CloudEvents specification version
+const SpecVersion = "1.0"
+
+// Type is the type of a CloudEvent, versioned under io.localpvcleaner
+type Type string
+
+const (
+	// TypeOrphanDetected is emitted when the node of a PV is found missing
+	TypeOrphanDetected Type = "io.localpvcleaner.orphan.detected.v1"
+	// TypeDeleted is emitted when a PV got deleted
+	TypeDeleted Type = "io.localpvcleaner.pv.deleted.v1"
+	// TypeSkipped is emitted when a PV is skipped by the cleanup
+	TypeSkipped Type = "io.localpvcleaner.pv.skipped.v1"
+	// TypeGuardTripped is emitted when a guard blocked the deletion of a PV
+	TypeGuardTripped Type = "io.localpvcleaner.guard.tripped.v1"
+)
+
+// Types are all the emitted types
+var Types = []Type{TypeOrphanDetected, TypeDeleted, TypeSkipped, TypeGuardTripped}
+
+// Mode is the HTTP content mode of the CloudEvents
+type Mode string
+
+const (
+	// ModeBinary carries the attributes in ce- headers and the data as the body
+	ModeBinary Mode = "binary"
+	// ModeStructured carries the whole event as an application/cloudevents+json body
+	ModeStructured Mode = "structured"
+)
+
+// Data is the payload of the emitted events
+type Data struct {
+	PV           string            `json:"pv"`
+	UID          string            `json:"uid,omitempty"`
+	StorageClass string            `json:"storageClass"`
+	Node         string            `json:"node,omitempty"`
+	Claim        string            `json:"claim,omitempty"`
+	Decision     string            `json:"decision"`
+	Reason       string            `json:"reason,omitempty"`
+	Evidence     map[string]string `json:"evidence,omitempty"`
+}
+
+// Event is a CloudEvent with a JSON data payload
+type Event struct {
+	ID      string
+	Source  string
+	Type    Type
+	Subject string
+	Time    time.Time
+	Data    Data
+}
+
+// structuredEvent is the JSON format of an Event
+type structuredEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            Type      `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            Data      `json:"data"`
+}
+
+// newRequest returns the HTTP request carrying the event in the given mode
+func newRequest(ctx context.Context, url string, mode Mode, event Event) (*http.Request, error) {
+	var body []byte
+	var err error
+	if mode == ModeStructured {
+		body, err = json.Marshal(structuredEvent{
+			SpecVersion:     SpecVersion,
+			ID:              event.ID,
+			Source:          event.Source,
+			Type:            event.Type,
+			Subject:         event.Subject,
+			Time:            event.Time,
+			DataContentType: "application/json",
+			Data:            event.Data,
+		})
+	} else {
+		body, err = json.Marshal(event.Data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if mode == ModeStructured {
+		req.Header.Set("Content-Type", "application/cloudevents+json; charset=utf-8")
+		return req, nil
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("ce-specversion", SpecVersion)
+	req.Header.Set("ce-id", event.ID)
+	req.Header.Set("ce-source", event.Source)
+	req.Header.Set("ce-type", string(event.Type))
+	req.Header.Set("ce-time", event.Time.UTC().Format(time.RFC3339Nano))
+	if event.Subject != "" {
+		req.Header.Set("ce-subject", event.Subject)
+	}
+	return req, nil
+}
+
+// send posts the event once and reports whether a failure is worth a retry
+func send(ctx context.Context, client *http.Client, url string, mode Mode, event Event) (bool, error) {
+	req, err := newRequest(ctx, url, mode, event)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// like the flag dump, the logged errors leave out the path and the query of the sink URL
+		var urlErr *neturl.Error
+		if errors.As(err, &urlErr) {
+			err = fmt.Errorf("CloudEvents sink %s %s: %w", urlErr.Op, req.URL.Host, urlErr.Err)
+		}
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 ||
+			resp.StatusCode == http.StatusRequestTimeout
+		return retry, fmt.Errorf("CloudEvents sink returned %s", resp.Status)
+	}
+	return false, nil
+}
@@ -0,0 +1,316 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// received is a CloudEvent decoded by a sink, along with the content type it was sent with
+type received struct {
+	contentType string
+	event       Event
+	err         error
+}
+
+// sink is a CloudEvents sink decoding the events of both modes, answering with the given statuses and then 202
+type sink struct {
+	mu       sync.Mutex
+	statuses []int
+	events   []received
+}
+
+// newSink starts a sink and returns its URL
+func newSink(t *testing.T, statuses ...int) (*sink, string) {
+	s := &sink{statuses: statuses}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		event, err := decode(req)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.events = append(s.events, received{contentType: req.Header.Get("Content-Type"), event: event, err: err})
+		status := http.StatusAccepted
+		if len(s.statuses) > 0 {
+			status, s.statuses = s.statuses[0], s.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return s, server.URL
+}
+
+// decode reads a CloudEvent from the ce- headers in binary mode, or from the body in structured mode
+func decode(req *http.Request) (Event, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return Event{}, err
+	}
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/cloudevents+json") {
+		var structured structuredEvent
+		if err := json.Unmarshal(body, &structured); err != nil {
+			return Event{}, err
+		}
+		if structured.SpecVersion != SpecVersion || structured.DataContentType != "application/json" {
+			return Event{}, fmt.Errorf("unexpected structured event %s", body)
+		}
+		return Event{ID: structured.ID, Source: structured.Source, Type: structured.Type,
+			Subject: structured.Subject, Time: structured.Time, Data: structured.Data}, nil
+	}
+
+	if req.Header.Get("ce-specversion") != SpecVersion {
+		return Event{}, fmt.Errorf("unexpected spec version %q", req.Header.Get("ce-specversion"))
+	}
+	event := Event{ID: req.Header.Get("ce-id"), Source: req.Header.Get("ce-source"),
+		Type: Type(req.Header.Get("ce-type")), Subject: req.Header.Get("ce-subject")}
+	if event.Time, err = time.Parse(time.RFC3339Nano, req.Header.Get("ce-time")); err != nil {
+		return Event{}, err
+	}
+	return event, json.Unmarshal(body, &event.Data)
+}
+
+// received returns the events received so far
+func (s *sink) received() []received {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]received(nil), s.events...)
+}
+
+// types returns the types of the events received so far
+func (s *sink) types() []Type {
+	var types []Type
+	for _, r := range s.received() {
+		types = append(types, r.event.Type)
+	}
+	return types
+}
+
+func newData(pv string) Data {
+	return Data{
+		PV: pv, UID: "uid-1", StorageClass: "topolvm", Node: "node-gone", Claim: "team-a/data", Decision: "delete",
+		Reason: "orphaned", Evidence: map[string]string{"nodeFound": "false"},
+	}
+}
+
+// start runs the emitter until the returned function cancels its context and waits for the drain
+func start(e *Emitter) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = e.Start(ctx)
+		close(done)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func TestParseType(t *testing.T) {
+	var tests = []struct {
+		name    string
+		want    Type
+		wantErr bool
+	}{
+		{name: "orphan-detected", want: TypeOrphanDetected},
+		{name: "deleted", want: TypeDeleted},
+		{name: "skipped", want: TypeSkipped},
+		{name: "guard-tripped", want: TypeGuardTripped},
+		{name: "io.localpvcleaner.pv.deleted.v1", want: TypeDeleted},
+		{name: "dry-run", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseType(tt.name)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	e, err := New(Options{})
+	require.NoError(t, err)
+	assert.Nil(t, e)
+	e.Emit(TypeDeleted, "pv-1", newData("pv-1"))
+
+	_, err = New(Options{Sink: "example.com/events"})
+	assert.Error(t, err)
+	_, err = New(Options{Sink: "https://example.com/events", Mode: "batched"})
+	assert.Error(t, err)
+}
+
+func TestEmitter_modes(t *testing.T) {
+	var tests = []struct {
+		mode            Mode
+		wantContentType string
+	}{
+		{mode: ModeBinary, wantContentType: "application/json"},
+		{mode: ModeStructured, wantContentType: "application/cloudevents+json; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			s, url := newSink(t)
+			e, err := New(Options{Sink: url, Mode: tt.mode, Source: "/clusters/prod", Types: []Type{TypeDeleted}})
+			require.NoError(t, err)
+			stop := start(e)
+			defer stop()
+
+			emittedAt := time.Now()
+			e.Emit(TypeSkipped, "pv-0", newData("pv-0"))
+			e.Emit(TypeDeleted, "pv-1", newData("pv-1"))
+			require.Eventually(t, func() bool {
+				return len(s.received()) == 1
+			}, 5*time.Second, 10*time.Millisecond, "Expected only the selected types to be emitted")
+
+			got := s.received()[0]
+			require.NoError(t, got.err)
+			assert.Equal(t, tt.wantContentType, got.contentType)
+			assert.NotEmpty(t, got.event.ID)
+			assert.WithinDuration(t, emittedAt, got.event.Time, time.Second)
+			got.event.ID, got.event.Time = "", time.Time{}
+			assert.Equal(t, Event{Source: "/clusters/prod", Type: TypeDeleted, Subject: "pv-1", Data: newData("pv-1")},
+				got.event)
+		})
+	}
+}
+
+func TestEmitter_outage(t *testing.T) {
+	s, url := newSink(t, 503, 503, 429)
+	e, err := New(Options{Sink: url, RetryBaseDelay: time.Millisecond, RetryMaxDelay: 5 * time.Millisecond})
+	require.NoError(t, err)
+	e.Emit(TypeOrphanDetected, "pv-1", newData("pv-1"))
+	e.Emit(TypeDeleted, "pv-1", newData("pv-1"))
+	stop := start(e)
+	defer stop()
+
+	require.Eventually(t, func() bool {
+		return len(s.received()) == 5
+	}, 5*time.Second, 10*time.Millisecond, "Expected the events to be delivered once the sink is back")
+	assert.Equal(t, []Type{TypeOrphanDetected, TypeOrphanDetected, TypeOrphanDetected, TypeOrphanDetected, TypeDeleted},
+		s.types(), "Expected the events in order")
+}
+
+func TestEmitter_deliver(t *testing.T) {
+	var tests = []struct {
+		name         string
+		statuses     []int
+		maxEventAge  time.Duration
+		wantRequests int
+		wantResult   string
+	}{
+		{name: "Delivered", wantRequests: 1, wantResult: resultSent},
+		{name: "Client errors are not retried", statuses: []int{400}, maxEventAge: time.Minute, wantRequests: 1,
+			wantResult: resultFailed},
+		{name: "Given up after the max event age", statuses: []int{500, 500, 500, 500}, maxEventAge: 30 * time.Millisecond,
+			wantRequests: 2, wantResult: resultExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, url := newSink(t, tt.statuses...)
+			e, err := New(Options{Sink: url, RetryBaseDelay: 20 * time.Millisecond, MaxEventAge: tt.maxEventAge})
+			require.NoError(t, err)
+
+			before := testutil.ToFloat64(eventsTotal.WithLabelValues(string(TypeSkipped), tt.wantResult))
+			item := queued{event: Event{ID: "1", Type: TypeSkipped, Time: time.Now(), Data: newData("pv-1")},
+				queuedAt: time.Now()}
+			e.deliver(context.Background(), item)
+
+			assert.Len(t, s.received(), tt.wantRequests)
+			assert.Equal(t, before+1, testutil.ToFloat64(eventsTotal.WithLabelValues(string(TypeSkipped), tt.wantResult)))
+		})
+	}
+}
+
+func TestEmitter_drain(t *testing.T) {
+	var tests = []struct {
+		name        string
+		statuses    []int
+		wantTypes   []Type
+		wantDropped float64
+	}{
+		{name: "Queued events are delivered on shutdown",
+			wantTypes: []Type{TypeOrphanDetected, TypeGuardTripped, TypeDeleted}},
+		{name: "Events left after the drain timeout are dropped", statuses: []int{503, 503, 503, 503, 503, 503},
+			wantDropped: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, url := newSink(t, tt.statuses...)
+			e, err := New(Options{Sink: url, RetryBaseDelay: 20 * time.Millisecond, DrainTimeout: 50 * time.Millisecond})
+			require.NoError(t, err)
+			dropped := func() float64 {
+				var sum float64
+				for _, eventType := range []Type{TypeOrphanDetected, TypeGuardTripped, TypeDeleted} {
+					sum += testutil.ToFloat64(eventsTotal.WithLabelValues(string(eventType), resultDropped))
+				}
+				return sum
+			}
+			before := dropped()
+
+			e.Emit(TypeOrphanDetected, "pv-1", newData("pv-1"))
+			e.Emit(TypeGuardTripped, "pv-1", newData("pv-1"))
+			e.Emit(TypeDeleted, "pv-2", newData("pv-2"))
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			require.NoError(t, e.Start(ctx))
+
+			if tt.wantTypes != nil {
+				assert.Equal(t, tt.wantTypes, s.types())
+			}
+			assert.Equal(t, tt.wantDropped, dropped()-before)
+			assert.Zero(t, len(e.queue))
+		})
+	}
+}
+
+func TestSend_networkErrorHidesTheURL(t *testing.T) {
+	_, err := send(context.Background(), http.DefaultClient, "http://127.0.0.1:1/events?token=s3cr3t", ModeBinary,
+		Event{Type: TypeDeleted, Data: newData("pv-1")})
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "s3cr3t")
+	assert.Contains(t, err.Error(), "127.0.0.1:1")
+}
+
+func TestEmitter_queueFull(t *testing.T) {
+	e, err := New(Options{Sink: "http://127.0.0.1:1", QueueSize: 1})
+	require.NoError(t, err)
+
+	before := testutil.ToFloat64(eventsTotal.WithLabelValues(string(TypeDeleted), resultDropped))
+	e.Emit(TypeDeleted, "pv-1", newData("pv-1"))
+	e.Emit(TypeDeleted, "pv-2", newData("pv-2"))
+	assert.Equal(t, before+1, testutil.ToFloat64(eventsTotal.WithLabelValues(string(TypeDeleted), resultDropped)))
+}
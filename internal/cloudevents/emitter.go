@@ -0,0 +1,266 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Options configures an Emitter
+type Options struct {
+	// Sink is the HTTP URL receiving the events
+	Sink string
+	// Mode is the HTTP content mode, binary by default
+	Mode Mode
+	// Source is the source attribute of the events
+	Source string
+	// Types are the emitted types, every type is emitted when empty
+	Types []Type
+	// QueueSize is the number of events queued before new ones are dropped
+	QueueSize int
+	// RetryBaseDelay is the delay before the first retry, doubled on every further retry
+	RetryBaseDelay time.Duration
+	// RetryMaxDelay caps the delay between two retries
+	RetryMaxDelay time.Duration
+	// MaxEventAge is how long an event is retried before it is given up, so the queue outlives short sink outages
+	MaxEventAge time.Duration
+	// Timeout bounds every HTTP request
+	Timeout time.Duration
+	// DrainTimeout bounds the delivery of the queued events once the emitter is stopped
+	DrainTimeout time.Duration
+}
+
+// ParseMode converts the given name to a Mode
+func ParseMode(name string) (Mode, error) {
+	switch Mode(name) {
+	case ModeBinary, ModeStructured:
+		return Mode(name), nil
+	default:
+		return "", fmt.Errorf("unsupported CloudEvents mode %q, expected binary or structured", name)
+	}
+}
+
+// ParseType converts a short name such as "orphan-detected" or a full type name to a Type
+func ParseType(name string) (Type, error) {
+	for _, t := range Types {
+		if name == string(t) || name == shortName(t) {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported CloudEvents type %q, expected orphan-detected, deleted, skipped or guard-tripped", name)
+}
+
+// shortName returns the short name of a Type
+func shortName(t Type) string {
+	switch t {
+	case TypeOrphanDetected:
+		return "orphan-detected"
+	case TypeDeleted:
+		return "deleted"
+	case TypeSkipped:
+		return "skipped"
+	case TypeGuardTripped:
+		return "guard-tripped"
+	}
+	return string(t)
+}
+
+// queued is an event waiting for its delivery
+type queued struct {
+	event    Event
+	queuedAt time.Time
+}
+
+// Emitter queues the events and delivers them in order to the sink, retrying while the sink is unavailable.
+// It implements the manager Runnable interface.
+type Emitter struct {
+	opts   Options
+	types  map[Type]bool
+	queue  chan queued
+	client *http.Client
+}
+
+// New returns an Emitter, nil when no sink is configured
+func New(opts Options) (*Emitter, error) {
+	if opts.Sink == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(opts.Sink, "http://") && !strings.HasPrefix(opts.Sink, "https://") {
+		return nil, fmt.Errorf("invalid CloudEvents sink URL %q", opts.Sink)
+	}
+	if opts.Mode == "" {
+		opts.Mode = ModeBinary
+	}
+	if _, err := ParseMode(string(opts.Mode)); err != nil {
+		return nil, err
+	}
+	if opts.Source == "" {
+		opts.Source = "local-pv-cleaner"
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 1000
+	}
+	if opts.RetryBaseDelay <= 0 {
+		opts.RetryBaseDelay = time.Second
+	}
+	if opts.RetryMaxDelay <= 0 {
+		opts.RetryMaxDelay = 30 * time.Second
+	}
+	if opts.MaxEventAge <= 0 {
+		opts.MaxEventAge = 10 * time.Minute
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.DrainTimeout <= 0 {
+		opts.DrainTimeout = 10 * time.Second
+	}
+
+	var types map[Type]bool
+	if len(opts.Types) > 0 {
+		types = map[Type]bool{}
+		for _, t := range opts.Types {
+			types[t] = true
+		}
+	}
+
+	return &Emitter{
+		opts:   opts,
+		types:  types,
+		queue:  make(chan queued, opts.QueueSize),
+		client: &http.Client{Timeout: opts.Timeout},
+	}, nil
+}
+
+// Emit queues an event of the given type without blocking, the event is dropped when the queue is full
+func (e *Emitter) Emit(t Type, subject string, data Data) {
+	if e == nil || (e.types != nil && !e.types[t]) {
+		return
+	}
+
+	now := time.Now()
+	event := Event{
+		ID:      string(uuid.NewUUID()),
+		Source:  e.opts.Source,
+		Type:    t,
+		Subject: subject,
+		Time:    now,
+		Data:    data,
+	}
+	select {
+	case e.queue <- queued{event: event, queuedAt: now}:
+		queueLength.Set(float64(len(e.queue)))
+	default:
+		eventsTotal.WithLabelValues(string(t), resultDropped).Inc()
+	}
+}
+
+// NeedLeaderElection makes sure only the leader, which does the cleanup, emits events
+func (e *Emitter) NeedLeaderElection() bool {
+	return true
+}
+
+// Start delivers the queued events until the context is cancelled, and then drains the queue
+// for up to the drain timeout
+func (e *Emitter) Start(ctx context.Context) error {
+	// the deliveries outlive the context by the drain timeout, so that a rollout does not lose the queued events
+	deliverCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	stop := context.AfterFunc(ctx, func() {
+		time.AfterFunc(e.opts.DrainTimeout, cancel)
+	})
+	defer stop()
+
+	for {
+		select {
+		case item := <-e.queue:
+			queueLength.Set(float64(len(e.queue)))
+			e.deliver(deliverCtx, item)
+		case <-ctx.Done():
+			e.drain(deliverCtx)
+			return nil
+		}
+	}
+}
+
+// drain delivers the queued events until the queue is empty or the context is cancelled,
+// the events left are dropped
+func (e *Emitter) drain(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	for {
+		select {
+		case item := <-e.queue:
+			queueLength.Set(float64(len(e.queue)))
+			if ctx.Err() != nil {
+				eventsTotal.WithLabelValues(string(item.event.Type), resultDropped).Inc()
+				continue
+			}
+			e.deliver(ctx, item)
+		default:
+			if ctx.Err() != nil {
+				logger.Info("Stopped draining the CloudEvents queue after the drain timeout")
+			}
+			return
+		}
+	}
+}
+
+// deliver sends an event, retrying with an exponential backoff until it is delivered, given up or the emitter stops
+func (e *Emitter) deliver(ctx context.Context, item queued) {
+	logger := log.FromContext(ctx)
+	delay := e.opts.RetryBaseDelay
+	for {
+		retry, err := send(ctx, e.client, e.opts.Sink, e.opts.Mode, item.event)
+		if err == nil {
+			eventsTotal.WithLabelValues(string(item.event.Type), resultSent).Inc()
+			return
+		}
+		if ctx.Err() != nil {
+			eventsTotal.WithLabelValues(string(item.event.Type), resultDropped).Inc()
+			return
+		}
+		if !retry {
+			logger.Error(err, "Failed to emit CloudEvent", "type", item.event.Type, "id", item.event.ID)
+			eventsTotal.WithLabelValues(string(item.event.Type), resultFailed).Inc()
+			return
+		}
+		if time.Since(item.queuedAt)+delay > e.opts.MaxEventAge {
+			logger.Error(err, "Gave up emitting CloudEvent", "type", item.event.Type, "id", item.event.ID,
+				"age", time.Since(item.queuedAt))
+			eventsTotal.WithLabelValues(string(item.event.Type), resultExpired).Inc()
+			return
+		}
+
+		logger.V(1).Info("Retrying CloudEvent", "type", item.event.Type, "id", item.event.ID, "delay", delay,
+			"error", err.Error())
+		eventRetriesTotal.Inc()
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			eventsTotal.WithLabelValues(string(item.event.Type), resultDropped).Inc()
+			return
+		}
+		delay = min(2*delay, e.opts.RetryMaxDelay)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Results of the events, used as the result label of the events metric
+const (
+	resultSent    = "sent"
+	resultFailed  = "failed"
+	resultDropped = "dropped"
+	resultExpired = "expired"
+)
+
+var (
+	eventsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "local_pv_cleaner_cloudevents_total",
+			Help: "Total number of CloudEvents by type and result, dropped counts events lost to a full queue or " +
+				"to the drain timeout on shutdown and expired events retried for too long",
+		},
+		[]string{"type", "result"},
+	)
+
+	eventRetriesTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "local_pv_cleaner_cloudevents_retries_total",
+			Help: "Total number of CloudEvents delivery retries",
+		},
+	)
+
+	queueLength = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "local_pv_cleaner_cloudevents_queue_length",
+			Help: "Number of CloudEvents waiting for their delivery",
+		},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(eventsTotal, eventRetriesTotal, queueLength)
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kavinraja-g/local-pv-cleaner/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=localpvcleaner.io,resources=orphanedvolumes,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=localpvcleaner.io,resources=orphanedvolumes/status,verbs=get;update;patch
+
+// approvalGuard holds the deletion of the PV until its OrphanedVolume is approved for the current evidence.
+// It creates the OrphanedVolume of a new candidate and expires the reviews given for a different evidence.
+func (r *PVCleanupController) approvalGuard(ctx context.Context, pv corev1.PersistentVolume, cause deletionCause,
+	action orphanAction) (*guardTrip, error) {
+	if !r.RequireApproval {
+		return nil, nil
+	}
+
+	var volume v1alpha1.OrphanedVolume
+	err := r.Client.Get(ctx, client.ObjectKey{Name: pv.Name}, &volume)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, err
+	}
+	if err != nil {
+		volume = v1alpha1.OrphanedVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: pv.Name},
+			Spec:       v1alpha1.OrphanedVolumeSpec{PersistentVolumeName: pv.Name},
+		}
+		if err := r.Budget.Wait(ctx, "create-orphaned-volume"); err != nil {
+			return nil, err
+		}
+		if err := r.Client.Create(ctx, &volume); err != nil {
+			return nil, err
+		}
+		r.event(&pv, corev1.EventTypeNormal, "ApprovalRequested",
+			fmt.Sprintf("Deletion of the PV (%s) waits for the approval of OrphanedVolume %s", cause, volume.Name))
+	}
+
+	status := volume.Status.DeepCopy()
+	status.Cause = string(cause)
+	status.Action = string(action)
+	status.Node = getNodeNameFromAffinity(pv.Spec.NodeAffinity, r.NodeSelectorKeys)
+	status.StorageClass = pv.Spec.StorageClassName
+	status.Claim = ""
+	if claim := pv.Spec.ClaimRef; claim != nil {
+		status.Claim = claim.Namespace + "/" + claim.Name
+	}
+	status.Evidence = r.auditEvidence(pv, cause)
+	status.NextMaintenanceWindow = r.nextMaintenanceWindow()
+	hash := evidenceHash(status)
+
+	// a new review is given for the evidence published on the OrphanedVolume
+	reviewed := status.ReviewedEvidenceHash
+	if reviewed == "" {
+		reviewed = status.EvidenceHash
+	}
+	approval := volume.Approval()
+	switch {
+	case approval == "":
+		status.ReviewedEvidenceHash = ""
+		status.ReviewedAt = nil
+		if status.Phase != v1alpha1.PhaseDetected && status.Phase != v1alpha1.PhaseExpired {
+			r.setPhase(status, v1alpha1.PhaseDetected, "Waiting for a review")
+		}
+	case reviewed != hash, status.Phase == v1alpha1.PhaseDeleted:
+		// the review was given for another evidence or for a deleted PV of the same name
+		if err := r.clearApproval(ctx, &volume); err != nil {
+			return nil, err
+		}
+		approval = ""
+		status.ReviewedEvidenceHash = ""
+		status.ReviewedAt = nil
+		r.setPhase(status, v1alpha1.PhaseExpired, "The evidence changed since the review, a new review is needed")
+	default:
+		if phase := v1alpha1.Phase(approval); status.Phase != phase {
+			status.ReviewedEvidenceHash = hash
+			status.ReviewedAt = &metav1.Time{Time: r.now()}
+			r.setPhase(status, phase, fmt.Sprintf("Deletion %s for the current evidence", approval))
+		}
+	}
+	status.EvidenceHash = hash
+
+	if !equality.Semantic.DeepEqual(&volume.Status, status) {
+		volume.Status = *status
+		if err := r.Client.Status().Update(ctx, &volume); err != nil {
+			return nil, err
+		}
+	}
+
+	switch approval {
+	case v1alpha1.Approved:
+		return nil, nil
+	case v1alpha1.Rejected:
+		return &guardTrip{Guard: guardApproval, Message: "Deletion rejected by OrphanedVolume " + volume.Name}, nil
+	default:
+		return &guardTrip{Guard: guardApproval, Message: "Waiting for the approval of OrphanedVolume " + volume.Name}, nil
+	}
+}
+
+// expireOrphanedVolume expires the OrphanedVolume of a PV that is no longer a deletion candidate,
+// so that a later deletion needs a new review
+func (r *PVCleanupController) expireOrphanedVolume(ctx context.Context, name string) error {
+	if !r.RequireApproval {
+		return nil
+	}
+
+	var volume v1alpha1.OrphanedVolume
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: name}, &volume); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	switch volume.Status.Phase {
+	case v1alpha1.PhaseDetected, v1alpha1.PhaseApproved, v1alpha1.PhaseRejected:
+	default:
+		return nil
+	}
+
+	if err := r.clearApproval(ctx, &volume); err != nil {
+		return err
+	}
+	volume.Status.ReviewedEvidenceHash = ""
+	volume.Status.ReviewedAt = nil
+	r.setPhase(&volume.Status, v1alpha1.PhaseExpired, "The PV is no longer a deletion candidate")
+	log.FromContext(ctx).Info("Expired OrphanedVolume", "pv", name)
+	return r.Client.Status().Update(ctx, &volume)
+}
+
+// orphanedVolumeDeleted records the deletion of the PV on its OrphanedVolume, failures are only logged
+// since the PV is gone already
+func (r *PVCleanupController) orphanedVolumeDeleted(ctx context.Context, pv corev1.PersistentVolume) {
+	if !r.RequireApproval {
+		return
+	}
+
+	var volume v1alpha1.OrphanedVolume
+	err := r.Client.Get(ctx, client.ObjectKey{Name: pv.Name}, &volume)
+	if err == nil {
+		r.setPhase(&volume.Status, v1alpha1.PhaseDeleted, "PV deleted")
+		err = r.Client.Status().Update(ctx, &volume)
+	}
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to record the deletion on the OrphanedVolume", "pv", pv.Name)
+	}
+}
+
+// clearApproval removes the review from the spec and the annotations of the OrphanedVolume
+func (r *PVCleanupController) clearApproval(ctx context.Context, volume *v1alpha1.OrphanedVolume) error {
+	if _, ok := volume.Annotations[v1alpha1.ApprovalAnnotation]; !ok && volume.Spec.Approval == "" {
+		return nil
+	}
+	patch := client.MergeFrom(volume.DeepCopy())
+	volume.Spec.Approval = ""
+	delete(volume.Annotations, v1alpha1.ApprovalAnnotation)
+	return r.Client.Patch(ctx, volume, patch)
+}
+
+// setPhase moves the OrphanedVolume to the given phase
+func (r *PVCleanupController) setPhase(status *v1alpha1.OrphanedVolumeStatus, phase v1alpha1.Phase, message string) {
+	status.Message = message
+	if status.Phase == phase {
+		return
+	}
+	now := metav1.Time{Time: r.now()}
+	if phase == v1alpha1.PhaseDetected {
+		status.DetectedAt = &now
+	}
+	status.Phase = phase
+	status.LastTransitionTime = &now
+	orphanedVolumeTransitionsTotal.WithLabelValues(string(phase)).Inc()
+}
+
+// evidenceHash identifies the evidence of an OrphanedVolume. The orphan time is left out, it falls back to the
+// time of the decision when it is not recorded yet.
+func evidenceHash(status *v1alpha1.OrphanedVolumeStatus) string {
+	h := sha256.New()
+	for _, value := range []string{status.Cause, status.Action, status.Node, status.StorageClass, status.Claim} {
+		_, _ = fmt.Fprintf(h, "%s\n", value)
+	}
+	for _, key := range slices.Sorted(maps.Keys(status.Evidence)) {
+		if key != "orphanedSince" {
+			_, _ = fmt.Fprintf(h, "%s=%s\n", key, status.Evidence[key])
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// enqueueOrphanedVolumePV maps an OrphanedVolume to the reconcile request of its PV
+func enqueueOrphanedVolumePV() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
+		volume, ok := obj.(*v1alpha1.OrphanedVolume)
+		if !ok || volume.Spec.PersistentVolumeName == "" {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: volume.Spec.PersistentVolumeName}}}
+	})
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kavinraja-g/local-pv-cleaner/api/v1alpha1"
+)
+
+func TestPVCleanupController_Reconcile_approval(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+	_ = v1alpha1.AddToScheme(s)
+
+	ctx := context.Background()
+	pv := newLocalPV("pv-1", "topolvm", "node-01")
+	pv.Spec.ClaimRef = &corev1.ObjectReference{Namespace: "team-a", Name: "data"}
+	fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(pv).
+		WithIndex(&storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV).
+		WithStatusSubresource(&v1alpha1.OrphanedVolume{}).Build()
+	recorder := record.NewFakeRecorder(100)
+	r := &PVCleanupController{
+		Client:            fakeClient,
+		NodeSelectorKeys:  []string{testNodeSelectorKey},
+		StorageClassNames: []string{"topolvm"},
+		RequeueDuration:   15 * time.Minute,
+		RequireApproval:   true,
+		Recorder:          recorder,
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pv.Name}}
+	volume := func() *v1alpha1.OrphanedVolume {
+		var volume v1alpha1.OrphanedVolume
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: pv.Name}, &volume))
+		return &volume
+	}
+	review := func(approval v1alpha1.Approval, annotation string) {
+		v := volume()
+		v.Spec.Approval = approval
+		if annotation != "" {
+			v.Annotations = map[string]string{v1alpha1.ApprovalAnnotation: annotation}
+		}
+		require.NoError(t, fakeClient.Update(ctx, v))
+	}
+	assertPVExists := func(exists bool) {
+		err := fakeClient.Get(ctx, client.ObjectKeyFromObject(pv), &corev1.PersistentVolume{})
+		assert.Equal(t, exists, err == nil)
+	}
+
+	// a new candidate is held until its OrphanedVolume is approved
+	result, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, result.RequeueAfter)
+	assertPVExists(true)
+	v := volume()
+	assert.Equal(t, v1alpha1.PhaseDetected, v.Status.Phase)
+	assert.Equal(t, "orphaned", v.Status.Cause)
+	assert.Equal(t, "delete", v.Status.Action)
+	assert.Equal(t, "node-01", v.Status.Node)
+	assert.Equal(t, "team-a/data", v.Status.Claim)
+	assert.Equal(t, "false", v.Status.Evidence["nodeFound"])
+	assert.NotEmpty(t, v.Status.EvidenceHash)
+	assert.NotNil(t, v.Status.DetectedAt)
+	assert.Contains(t, <-recorder.Events, "ApprovalRequested")
+	assert.Contains(t, <-recorder.Events, "Waiting for the approval of OrphanedVolume pv-1")
+
+	// a rejection keeps the PV
+	review(v1alpha1.Rejected, "")
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assertPVExists(true)
+	assert.Equal(t, v1alpha1.PhaseRejected, volume().Status.Phase)
+	assert.Equal(t, v.Status.EvidenceHash, volume().Status.ReviewedEvidenceHash)
+
+	// the node comes back, the review expires
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-01"}}
+	require.NoError(t, fakeClient.Create(ctx, node))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	v = volume()
+	assert.Equal(t, v1alpha1.PhaseExpired, v.Status.Phase)
+	assert.Empty(t, v.Spec.Approval)
+	assert.Empty(t, v.Status.ReviewedEvidenceHash)
+
+	// the node is gone again and the PV got released before the approval was seen, the approval expires
+	require.NoError(t, fakeClient.Delete(ctx, node))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	review("", "approved")
+	pv.Status.Phase = corev1.VolumeReleased
+	require.NoError(t, fakeClient.Status().Update(ctx, pv))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assertPVExists(true)
+	v = volume()
+	assert.Equal(t, v1alpha1.PhaseExpired, v.Status.Phase)
+	assert.NotContains(t, v.Annotations, v1alpha1.ApprovalAnnotation)
+	assert.Equal(t, "Released", v.Status.Evidence["phase"])
+
+	// an approval of the current evidence lets the PV be deleted
+	review("", "Approved")
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assertPVExists(false)
+	v = volume()
+	assert.Equal(t, v1alpha1.PhaseDeleted, v.Status.Phase)
+	assert.NotNil(t, v.Status.ReviewedAt)
+
+	// the deletion is final
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.PhaseDeleted, volume().Status.Phase)
+}
+
+func TestEvidenceHash(t *testing.T) {
+	status := &v1alpha1.OrphanedVolumeStatus{
+		Cause:    "orphaned",
+		Node:     "node-01",
+		Evidence: map[string]string{"nodeFound": "false", "orphanedSince": "2025-03-01T11:00:00Z"},
+	}
+	hash := evidenceHash(status)
+
+	status.Evidence["orphanedSince"] = "2025-03-01T12:00:00Z"
+	assert.Equal(t, hash, evidenceHash(status), "Expected the orphan time to be left out")
+	status.Claim = "team-a/data"
+	assert.NotEqual(t, hash, evidenceHash(status))
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/audit"
+)
+
+// auditEvidence returns the facts behind the deletion decision of a PV
+func (r *PVCleanupController) auditEvidence(pv corev1.PersistentVolume, cause deletionCause) map[string]string {
+	return r.policy().evidence(pv, r.baseFacts(pv), cause)
+}
+
+// audit writes an audit record of an action on the PV, failures are logged but never block the cleanup
+func (r *PVCleanupController) audit(ctx context.Context, pv corev1.PersistentVolume, action audit.Action,
+	cause deletionCause, message string, evidence map[string]string) {
+	if r.Audit == nil {
+		return
+	}
+
+	record := audit.Record{
+		Time:         r.now().UTC(),
+		Action:       action,
+		PV:           pv.Name,
+		UID:          pv.UID,
+		StorageClass: pv.Spec.StorageClassName,
+		Node:         getNodeNameFromAffinity(pv.Spec.NodeAffinity, r.NodeSelectorKeys),
+		Cause:        string(cause),
+		Message:      message,
+		Evidence:     evidence,
+		Controller:   r.Identity,
+	}
+	if claim := pv.Spec.ClaimRef; claim != nil {
+		record.Claim = claim.Namespace + "/" + claim.Name
+	}
+
+	if err := r.Audit.Write(ctx, record); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to write audit record", "pv", pv.Name, "action", action)
+		auditFailuresTotal.WithLabelValues(string(action)).Inc()
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/audit"
+)
+
+// recordingSink keeps the audit records in memory
+type recordingSink struct {
+	mu      sync.Mutex
+	records []audit.Record
+}
+
+func (s *recordingSink) Write(_ context.Context, record audit.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+	return nil
+}
+
+func TestPVCleanupController_audit(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	newPV := func() *corev1.PersistentVolume {
+		pv := newLocalPV("pv-1", "topolvm", "node-gone")
+		pv.UID = "uid-1"
+		pv.Spec.ClaimRef = &corev1.ObjectReference{Namespace: "team-a", Name: "data"}
+		return pv
+	}
+	held := newPV()
+	held.Annotations = map[string]string{RetainUntilAnnotation: "2025-03-02T00:00:00Z"}
+	forced := newPV()
+	forced.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimDelete
+	forced.Status.Phase = corev1.VolumeReleased
+	forced.Finalizers = []string{provisionerFinalizer}
+
+	var tests = []struct {
+		name        string
+		pv          *corev1.PersistentVolume
+		dryRun      bool
+		wantActions []audit.Action
+	}{
+		{name: "Deleted PV", pv: newPV(), wantActions: []audit.Action{audit.ActionDelete}},
+		{name: "DryRun", pv: newPV(), dryRun: true, wantActions: []audit.Action{audit.ActionDryRun}},
+		{name: "Guard trip", pv: held, wantActions: []audit.Action{audit.ActionGuardTrip}},
+		{
+			name:        "Force deleted PV",
+			pv:          forced,
+			wantActions: []audit.Action{audit.ActionFinalizerStrip, audit.ActionDelete},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &recordingSink{}
+			r := &PVCleanupController{
+				Client: crFake.NewClientBuilder().WithScheme(s).WithObjects(tt.pv).
+					WithIndex(&storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV).Build(),
+				DryRun:           tt.dryRun,
+				NodeSelectorKeys: []string{testNodeSelectorKey},
+				Audit:            sink,
+				Identity:         "local-pv-cleaner-0",
+				Clock:            clocktesting.NewFakePassiveClock(now),
+			}
+
+			_, _, err := r.deleteOrphanedPV(context.Background(), *tt.pv)
+			require.NoError(t, err)
+
+			var actions []audit.Action
+			for _, record := range sink.records {
+				actions = append(actions, record.Action)
+				assert.Equal(t, now, record.Time)
+				assert.Equal(t, "pv-1", record.PV)
+				assert.Equal(t, "uid-1", string(record.UID))
+				assert.Equal(t, "topolvm", record.StorageClass)
+				assert.Equal(t, "team-a/data", record.Claim)
+				assert.Equal(t, "node-gone", record.Node)
+				assert.Equal(t, string(causeOrphaned), record.Cause)
+				assert.Equal(t, "local-pv-cleaner-0", record.Controller)
+				assert.Equal(t, "false", record.Evidence["nodeFound"])
+			}
+			assert.Equal(t, tt.wantActions, actions)
+			if tt.wantActions[0] == audit.ActionGuardTrip {
+				assert.Equal(t, guardRetentionHold, sink.records[0].Evidence["guard"])
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/backup"
+)
+
+// apiReader returns the reader used for the objects the cache only holds as metadata
+func (r *PVCleanupController) apiReader() client.Reader {
+	if r.APIReader == nil {
+		return r.Client
+	}
+	return r.APIReader
+}
+
+// backupPV stores the manifests of the PV and of its bound claim before the PV gets deleted.
+// It returns the name of the snapshot, empty when backups are disabled.
+func (r *PVCleanupController) backupPV(ctx context.Context, pv corev1.PersistentVolume,
+	cause deletionCause) (string, error) {
+	if r.Backup == nil {
+		return "", nil
+	}
+	logger := log.FromContext(ctx)
+
+	snapshot := &backup.Snapshot{TakenAt: r.now().UTC(), Cause: string(cause), PersistentVolume: pv}
+	if claim := pv.Spec.ClaimRef; claim != nil {
+		// the cache only holds the metadata of the claims
+		var pvc corev1.PersistentVolumeClaim
+		err := r.apiReader().Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: claim.Name}, &pvc)
+		if client.IgnoreNotFound(err) != nil {
+			return "", err
+		}
+		if err == nil && (claim.UID == "" || claim.UID == pvc.UID) {
+			snapshot.PersistentVolumeClaim = &pvc
+		}
+	}
+
+	if err := r.Budget.Wait(ctx, "backup"); err != nil {
+		return "", err
+	}
+	if err := r.Backup.Save(ctx, snapshot); err != nil {
+		logger.Error(err, "Failed to back up PV", "pv", pv.Name)
+		backupFailuresTotal.WithLabelValues(pv.Spec.StorageClassName).Inc()
+		return "", err
+	}
+	logger.V(1).Info("Backed up PV", "pv", pv.Name, "snapshot", snapshot.Name())
+
+	return snapshot.Name(), nil
+}
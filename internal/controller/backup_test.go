@@ -0,0 +1,115 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/backup"
+)
+
+// failingStore is a backup store that cannot save anything
+type failingStore struct {
+	backup.Store
+}
+
+func (failingStore) Save(context.Context, *backup.Snapshot) error {
+	return errors.New("store unavailable")
+}
+
+func TestPVCleanupController_backupBeforeDelete(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	newObjects := func(pvcUID types.UID) (*corev1.PersistentVolume, *corev1.PersistentVolumeClaim) {
+		pv := newLocalPV("pv-1", "topolvm", "node-gone")
+		pv.Spec.CSI = &corev1.CSIPersistentVolumeSource{Driver: "topolvm.io", VolumeHandle: "handle-1"}
+		pv.Spec.ClaimRef = &corev1.ObjectReference{Namespace: "team-a", Name: "data", UID: "pvc-uid"}
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "data", UID: pvcUID},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-1"},
+		}
+		return pv, pvc
+	}
+
+	var tests = []struct {
+		name      string
+		pvcUID    types.UID
+		failing   bool
+		wantClaim bool
+	}{
+		{name: "PV and its claim are backed up", pvcUID: "pvc-uid", wantClaim: true},
+		{name: "A recreated claim is not backed up", pvcUID: "recreated-pvc-uid"},
+		{name: "A failed backup holds back the deletion", pvcUID: "pvc-uid", failing: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			pv, pvc := newObjects(tt.pvcUID)
+			fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(pv, pvc).
+				WithIndex(&storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV).Build()
+			store, err := backup.NewDirStore(t.TempDir(), backup.Retention{})
+			require.NoError(t, err)
+			r := &PVCleanupController{
+				Client:           fakeClient,
+				NodeSelectorKeys: []string{testNodeSelectorKey},
+				Backup:           store,
+				Clock:            clocktesting.NewFakePassiveClock(now),
+			}
+			if tt.failing {
+				r.Backup = failingStore{}
+			}
+
+			_, outcome, err := r.deleteOrphanedPV(ctx, *pv)
+			getErr := fakeClient.Get(ctx, client.ObjectKey{Name: pv.Name}, &corev1.PersistentVolume{})
+			if tt.failing {
+				assert.Error(t, err)
+				assert.Equal(t, outcomeError, outcome)
+				assert.NoError(t, getErr, "Expected the PV to be kept")
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, apierrors.IsNotFound(getErr), "Expected the PV to be deleted")
+
+			latest, err := backup.Latest(ctx, store, pv.Name)
+			require.NoError(t, err)
+			snapshot, err := store.Load(ctx, latest.Name)
+			require.NoError(t, err)
+			assert.True(t, now.Equal(snapshot.TakenAt))
+			assert.Equal(t, string(causeOrphaned), snapshot.Cause)
+			assert.Equal(t, "handle-1", snapshot.PersistentVolume.Spec.CSI.VolumeHandle)
+			assert.Equal(t, tt.wantClaim, snapshot.PersistentVolumeClaim != nil)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// MutationBudget is a token bucket shared by all the workers, limiting the rate of API mutating operations.
+// A nil MutationBudget does not limit anything.
+type MutationBudget struct {
+	limiter *rate.Limiter
+}
+
+// NewMutationBudget returns a budget allowing qps mutations per second with the given burst,
+// or nil when qps is not positive
+func NewMutationBudget(qps float64, burst int) *MutationBudget {
+	if qps <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &MutationBudget{limiter: rate.NewLimiter(rate.Limit(qps), burst)}
+}
+
+// Wait blocks until the budget allows one more mutation or the context is done
+func (b *MutationBudget) Wait(ctx context.Context, operation string) error {
+	if b == nil {
+		return nil
+	}
+
+	start := time.Now()
+	if err := b.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	mutationWaitSeconds.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+
+	return nil
+}
+
+// newRateLimiter returns the workqueue rate limiter: a per-item exponential backoff between the given bounds,
+// capped by an overall token bucket of qps requeues per second with the given burst.
+// The zero values fall back to the defaults of controller-runtime.
+func newRateLimiter(baseDelay, maxDelay time.Duration, qps float64,
+	burst int) workqueue.TypedRateLimiter[reconcile.Request] {
+	if baseDelay <= 0 {
+		baseDelay = 5 * time.Millisecond
+	}
+	if maxDelay <= 0 {
+		maxDelay = 1000 * time.Second
+	}
+	if qps <= 0 {
+		qps = 10
+	}
+	if burst <= 0 {
+		burst = 100
+	}
+
+	return workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](baseDelay, maxDelay),
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(qps), burst)},
+	)
+}
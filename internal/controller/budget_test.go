@@ -0,0 +1,178 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestMutationBudget_unlimited(t *testing.T) {
+	budget := NewMutationBudget(0, 10)
+	assert.Nil(t, budget)
+	assert.NoError(t, budget.Wait(context.Background(), "delete-pv"))
+}
+
+func TestMutationBudget_concurrentWait(t *testing.T) {
+	const workers, burst, qps = 50, 5, 500
+	budget := NewMutationBudget(qps, burst)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	start := time.Now()
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- budget.Wait(context.Background(), "delete-pv")
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	// everything beyond the burst has to wait for a token
+	minElapsed := time.Duration(float64(workers-burst) / qps * float64(time.Second))
+	assert.GreaterOrEqual(t, time.Since(start), minElapsed*8/10)
+}
+
+func TestMutationBudget_contextDone(t *testing.T) {
+	budget := NewMutationBudget(0.001, 1)
+	require.NoError(t, budget.Wait(context.Background(), "delete-pv"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Error(t, budget.Wait(ctx, "delete-pv"))
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	var tests = []struct {
+		name      string
+		qps       float64
+		burst     int
+		wantDelay time.Duration
+	}{
+		{name: "Defaults", wantDelay: 5 * time.Millisecond},
+		{name: "Overall bucket", qps: 2, burst: 1, wantDelay: 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := newRateLimiter(0, 0, tt.qps, tt.burst)
+			limiter.When(reconcile.Request{NamespacedName: types.NamespacedName{Name: "pv-1"}})
+			// a second PV is only delayed by its own backoff and the overall bucket
+			delay := limiter.When(reconcile.Request{NamespacedName: types.NamespacedName{Name: "pv-2"}})
+			assert.InDelta(t, tt.wantDelay, delay, float64(50*time.Millisecond))
+		})
+	}
+}
+
+func TestPVCleanupController_concurrentReconcile(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+	_ = storagev1.AddToScheme(s)
+
+	const orphans, released, healthy = 20, 10, 10
+	objects := []client.Object{&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-01"}}}
+	var names []string
+	for i := range orphans {
+		pv := newLocalPV(fmt.Sprintf("pv-orphan-%d", i), "topolvm", fmt.Sprintf("node-gone-%d", i))
+		objects = append(objects, pv)
+		names = append(names, pv.Name)
+	}
+	for i := range released {
+		pv := newLocalPV(fmt.Sprintf("pv-released-%d", i), "topolvm", "node-01")
+		pv.Status.Phase = corev1.VolumeReleased
+		objects = append(objects, pv)
+		names = append(names, pv.Name)
+	}
+	for i := range healthy {
+		pv := newLocalPV(fmt.Sprintf("pv-healthy-%d", i), "topolvm", "node-01")
+		objects = append(objects, pv)
+		names = append(names, pv.Name)
+	}
+
+	ctx := context.Background()
+	fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(objects...).
+		WithIndex(&storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV).
+		Build()
+	r := &PVCleanupController{
+		Client:           fakeClient,
+		NodeSelectorKeys: []string{testNodeSelectorKey},
+		RequeueDuration:  time.Minute,
+		ReleasedTTL:      time.Hour,
+		Budget:           NewMutationBudget(10000, 1),
+	}
+
+	// every PV is reconciled twice by a pool of workers, like a requeue racing with a sweep would
+	requests := make(chan reconcile.Request, 2*len(names))
+	for range 2 {
+		for _, name := range names {
+			requests <- reconcile.Request{NamespacedName: types.NamespacedName{Name: name}}
+		}
+	}
+	close(requests)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*len(names))
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for req := range requests {
+				if _, err := r.Reconcile(ctx, req); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		// a worker may lose the race to patch or delete a PV another worker just changed
+		assert.True(t, client.IgnoreNotFound(err) == nil || apierrors.IsConflict(err), "Unexpected error: %v", err)
+	}
+
+	var pvList corev1.PersistentVolumeList
+	require.NoError(t, fakeClient.List(ctx, &pvList))
+	assert.Len(t, pvList.Items, released+healthy, "Expected every orphaned PV to be deleted")
+	for _, pv := range pvList.Items {
+		if pv.Status.Phase == corev1.VolumeReleased {
+			assert.Contains(t, pv.Annotations, ReleasedAtAnnotation, "Expected release of %s to be tracked", pv.Name)
+		} else {
+			assert.NotContains(t, pv.Annotations, ReleasedAtAnnotation)
+		}
+	}
+}
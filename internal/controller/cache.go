@@ -0,0 +1,141 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/utils/strings/slices"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// lastAppliedAnnotation is set by kubectl apply and holds a full copy of the applied object
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+var (
+	nodeGVK      = corev1.SchemeGroupVersion.WithKind("Node")
+	pvcGVK       = corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim")
+	namespaceGVK = corev1.SchemeGroupVersion.WithKind("Namespace")
+)
+
+// newMetadata returns an empty metadata-only object of the given kind.
+// Reads through it are served by a metadata informer, which does not cache the spec and status.
+func newMetadata(gvk schema.GroupVersionKind) *metav1.PartialObjectMetadata {
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(gvk)
+	return obj
+}
+
+// newMetadataList returns an empty metadata-only list of the given kind
+func newMetadataList(gvk schema.GroupVersionKind) *metav1.PartialObjectMetadataList {
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	return list
+}
+
+// CacheOptions returns the manager cache options, keeping the footprint of the cached objects small.
+// Only the PVs matching the given label selector are cached, all of them when it is nil.
+// The only ConfigMap cached is the given pause ConfigMap, if any.
+func CacheOptions(pvLabelSelector labels.Selector, storageClassNames []string,
+	pauseConfigMap client.ObjectKey) cache.Options {
+	opts := cache.Options{
+		DefaultTransform: transformMetadata,
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.PersistentVolume{}: {
+				Label:     pvLabelSelector,
+				Transform: transformPV(storageClassNames),
+			},
+			newMetadata(nodeGVK): {
+				Transform: transformNodeMetadata,
+			},
+		},
+	}
+	if pauseConfigMap.Name != "" {
+		opts.ByObject[&corev1.ConfigMap{}] = cache.ByObject{
+			Namespaces: map[string]cache.Config{pauseConfigMap.Namespace: {}},
+			Field:      fields.OneTermEqualSelector("metadata.name", pauseConfigMap.Name),
+		}
+	}
+	return opts
+}
+
+// stripMetadata drops the managed fields and the last applied configuration of an object
+func stripMetadata(obj metav1.Object) {
+	if obj.GetManagedFields() != nil {
+		obj.SetManagedFields(nil)
+	}
+	if annotations := obj.GetAnnotations(); annotations != nil {
+		delete(annotations, lastAppliedAnnotation)
+	}
+}
+
+// transformMetadata is the default cache transform
+func transformMetadata(in any) (any, error) {
+	if obj, err := meta.Accessor(in); err == nil {
+		stripMetadata(obj)
+	}
+	return in, nil
+}
+
+// transformNodeMetadata keeps the name and labels of a Node, which is all the controller uses
+func transformNodeMetadata(in any) (any, error) {
+	obj, err := meta.Accessor(in)
+	if err != nil {
+		return in, nil
+	}
+
+	stripMetadata(obj)
+	obj.SetAnnotations(nil)
+	obj.SetOwnerReferences(nil)
+	return in, nil
+}
+
+// transformPV drops the fields of PVs outside of the given storage classes that the controller never reads,
+// such as the volume source with its CSI attributes and the claim
+func transformPV(storageClassNames []string) toolscache.TransformFunc {
+	return func(in any) (any, error) {
+		pv, ok := in.(*corev1.PersistentVolume)
+		if !ok {
+			return transformMetadata(in)
+		}
+
+		stripMetadata(pv)
+		if len(storageClassNames) == 0 || slices.Contains(storageClassNames, pv.Spec.StorageClassName) {
+			return pv, nil
+		}
+
+		pv.Spec.PersistentVolumeSource = corev1.PersistentVolumeSource{}
+		pv.Spec.MountOptions = nil
+		pv.Spec.ClaimRef = nil
+		pv.Status.Message = ""
+		pv.Status.Reason = ""
+		for key := range pv.Annotations {
+			if !strings.HasPrefix(key, annotationPrefix) {
+				delete(pv.Annotations, key)
+			}
+		}
+		return pv, nil
+	}
+}
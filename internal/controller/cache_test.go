@@ -0,0 +1,186 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestTransformPV(t *testing.T) {
+	newPV := func(storageClass string) *corev1.PersistentVolume {
+		pv := newLocalPV("pv-1", storageClass, "node-01")
+		pv.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+		pv.Annotations = map[string]string{
+			lastAppliedAnnotation:             "{}",
+			"pv.kubernetes.io/provisioned-by": "topolvm.io",
+			SkipAnnotation:                    "false",
+		}
+		pv.Spec.CSI = &corev1.CSIPersistentVolumeSource{Driver: "topolvm.io", VolumeHandle: "handle"}
+		pv.Spec.ClaimRef = &corev1.ObjectReference{Namespace: "team-a", Name: "data"}
+		return pv
+	}
+	transform := transformPV([]string{"topolvm"})
+
+	out, err := transform(newPV("topolvm"))
+	require.NoError(t, err)
+	managed := out.(*corev1.PersistentVolume)
+	assert.Nil(t, managed.ManagedFields)
+	assert.NotContains(t, managed.Annotations, lastAppliedAnnotation)
+	assert.Contains(t, managed.Annotations, "pv.kubernetes.io/provisioned-by")
+	assert.NotNil(t, managed.Spec.CSI)
+	assert.NotNil(t, managed.Spec.ClaimRef)
+
+	out, err = transform(newPV("openebs"))
+	require.NoError(t, err)
+	unmanaged := out.(*corev1.PersistentVolume)
+	assert.Nil(t, unmanaged.Spec.CSI)
+	assert.Nil(t, unmanaged.Spec.ClaimRef)
+	assert.Equal(t, map[string]string{SkipAnnotation: "false"}, unmanaged.Annotations)
+	assert.Equal(t, "openebs", unmanaged.Spec.StorageClassName)
+	assert.NotNil(t, unmanaged.Spec.NodeAffinity, "Expected the node affinity to be kept")
+}
+
+func TestCacheOptions_pauseConfigMap(t *testing.T) {
+	opts := CacheOptions(nil, nil, client.ObjectKey{})
+	for obj := range opts.ByObject {
+		_, isConfigMap := obj.(*corev1.ConfigMap)
+		assert.False(t, isConfigMap, "Expected no ConfigMap to be cached without a pause ConfigMap")
+	}
+
+	opts = CacheOptions(nil, nil, client.ObjectKey{Namespace: "ops", Name: "pause"})
+	for obj, byObject := range opts.ByObject {
+		if _, ok := obj.(*corev1.ConfigMap); ok {
+			assert.Equal(t, map[string]cache.Config{"ops": {}}, byObject.Namespaces)
+			assert.Equal(t, "metadata.name=pause", byObject.Field.String())
+			return
+		}
+	}
+	t.Fatal("Expected the pause ConfigMap to be cached")
+}
+
+func TestTransformNodeMetadata(t *testing.T) {
+	node := newBenchmarkNode()
+	obj := &metav1.PartialObjectMetadata{ObjectMeta: *node.ObjectMeta.DeepCopy()}
+
+	out, err := transformNodeMetadata(obj)
+	require.NoError(t, err)
+	meta := out.(*metav1.PartialObjectMetadata)
+	assert.Equal(t, node.Name, meta.Name)
+	assert.Equal(t, node.Labels, meta.Labels)
+	assert.Nil(t, meta.Annotations)
+	assert.Nil(t, meta.ManagedFields)
+}
+
+// newBenchmarkNode returns a Node with a status and metadata the size of a typical cloud node
+func newBenchmarkNode() *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "ip-10-0-12-34.eu-west-1.compute.internal",
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("16"),
+				corev1.ResourceMemory: resource.MustParse("64Gi"),
+			},
+			NodeInfo: corev1.NodeSystemInfo{KernelVersion: "6.1.0", KubeletVersion: "v1.32.1", OSImage: "Bottlerocket"},
+		},
+	}
+	for i := range 30 {
+		node.Labels[fmt.Sprintf("example.com/label-%d", i)] = fmt.Sprintf("value-%d", i)
+	}
+	for i := range 10 {
+		node.Annotations[fmt.Sprintf("example.com/annotation-%d", i)] = strings.Repeat("a", 256)
+	}
+	for i := range 5 {
+		node.ManagedFields = append(node.ManagedFields, metav1.ManagedFieldsEntry{
+			Manager:  fmt.Sprintf("manager-%d", i),
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(strings.Repeat("f", 4096))},
+		})
+	}
+	for i := range 8 {
+		node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{
+			Type: corev1.NodeConditionType(fmt.Sprintf("Condition%d", i)), Status: corev1.ConditionFalse,
+			Reason: "KubeletHasSufficientResources", Message: strings.Repeat("m", 64),
+		})
+	}
+	for i := range 60 {
+		node.Status.Images = append(node.Status.Images, corev1.ContainerImage{
+			Names: []string{
+				fmt.Sprintf("registry.example.com/team/image-%d@sha256:%s", i, strings.Repeat("0", 64)),
+				fmt.Sprintf("registry.example.com/team/image-%d:v1.2.3", i),
+			},
+			SizeBytes: 123456789,
+		})
+	}
+
+	return node
+}
+
+const benchmarkNodes = 1000
+
+// benchmarkCacheFootprint reports the heap retained per node by the object the cache would store for it
+func benchmarkCacheFootprint(b *testing.B, store func(node *corev1.Node) any) {
+	node := newBenchmarkNode()
+	objs := make([]any, benchmarkNodes)
+	var retained uint64
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		for j := range objs {
+			objs[j] = store(node)
+		}
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		if after.HeapAlloc > before.HeapAlloc {
+			retained += after.HeapAlloc - before.HeapAlloc
+		}
+		clear(objs)
+	}
+	b.ReportMetric(float64(retained)/float64(b.N*benchmarkNodes), "retained-B/node")
+}
+
+func BenchmarkNodeCacheFootprint(b *testing.B) {
+	b.Run("FullNode", func(b *testing.B) {
+		benchmarkCacheFootprint(b, func(node *corev1.Node) any {
+			obj, _ := transformMetadata(node.DeepCopy())
+			return obj
+		})
+	})
+	b.Run("NodeMetadata", func(b *testing.B) {
+		benchmarkCacheFootprint(b, func(node *corev1.Node) any {
+			obj, _ := transformNodeMetadata(&metav1.PartialObjectMetadata{ObjectMeta: *node.ObjectMeta.DeepCopy()})
+			return obj
+		})
+	})
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/cloudevents"
+)
+
+// emit queues a CloudEvent about the PV if an emitter is configured
+func (r *PVCleanupController) emit(pv corev1.PersistentVolume, eventType cloudevents.Type, outcome reconcileOutcome,
+	reason string, evidence map[string]string) {
+	if r.CloudEvents == nil {
+		return
+	}
+
+	data := cloudevents.Data{
+		PV:           pv.Name,
+		UID:          string(pv.UID),
+		StorageClass: pv.Spec.StorageClassName,
+		Node:         getNodeNameFromAffinity(pv.Spec.NodeAffinity, r.NodeSelectorKeys),
+		Decision:     string(outcome),
+		Reason:       reason,
+		Evidence:     evidence,
+	}
+	if claim := pv.Spec.ClaimRef; claim != nil {
+		data.Claim = claim.Namespace + "/" + claim.Name
+	}
+	r.CloudEvents.Emit(eventType, pv.Name, data)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/cloudevents"
+)
+
+func TestPVCleanupController_cloudEvents(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+	_ = storagev1.AddToScheme(s)
+
+	var mu sync.Mutex
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		got = append(got, req.Header.Get("ce-type")+"/"+req.Header.Get("ce-subject"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	emitter, err := cloudevents.New(cloudevents.Options{Sink: server.URL})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = emitter.Start(ctx)
+	}()
+
+	retained := newLocalPV("pv-retained", "topolvm", "node-gone")
+	retained.Annotations = map[string]string{RetainUntilAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339)}
+	pvs := []*corev1.PersistentVolume{
+		newLocalPV("pv-orphan", "topolvm", "node-gone"),
+		newLocalPV("pv-other-class", "openebs", "node-gone"),
+		retained,
+	}
+	newController := func() *PVCleanupController {
+		return &PVCleanupController{
+			Client: crFake.NewClientBuilder().WithScheme(s).WithObjects(pvs[0], pvs[1], pvs[2]).
+				WithIndex(&storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV).
+				Build(),
+			NodeSelectorKeys:  []string{testNodeSelectorKey},
+			StorageClassNames: []string{"topolvm"},
+			CloudEvents:       emitter,
+		}
+	}
+	reconcilePV := func(r *PVCleanupController, name string) {
+		_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+		require.NoError(t, err)
+	}
+
+	r := newController()
+	// a skipped PV is only reported once
+	reconcilePV(r, "pv-other-class")
+	reconcilePV(r, "pv-other-class")
+	// a PV held by a guard is only detected once
+	reconcilePV(r, "pv-retained")
+	reconcilePV(r, "pv-retained")
+	reconcilePV(r, "pv-orphan")
+
+	want := []string{
+		string(cloudevents.TypeSkipped) + "/pv-other-class",
+		string(cloudevents.TypeOrphanDetected) + "/pv-retained",
+		string(cloudevents.TypeGuardTripped) + "/pv-retained",
+		string(cloudevents.TypeGuardTripped) + "/pv-retained",
+		string(cloudevents.TypeOrphanDetected) + "/pv-orphan",
+		string(cloudevents.TypeDeleted) + "/pv-orphan",
+	}
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) == len(want)
+	}, 5*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, want, got)
+}
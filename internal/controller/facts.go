@@ -0,0 +1,134 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Assess gathers the facts about a PV and evaluates them against the policy of the controller,
+// without changing anything
+func (r *PVCleanupController) Assess(ctx context.Context, pv corev1.PersistentVolume) (Decision, error) {
+	facts, err := r.gatherFacts(ctx, pv)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to gather facts about PV", "pv", pv.Name)
+		return Decision{}, err
+	}
+
+	return r.policy().Evaluate(pv, facts), nil
+}
+
+// gatherFacts reads what the decision engine needs to know about a PV from the API.
+// Lookups the policy makes no use of for the PV are left out.
+func (r *PVCleanupController) gatherFacts(ctx context.Context, pv corev1.PersistentVolume) (Facts, error) {
+	p := r.policy()
+	facts := r.baseFacts(pv)
+	if p.filter(pv) != "" || isTrue(pv.Annotations, SkipAnnotation) {
+		return facts, nil
+	}
+
+	var err error
+	facts.Claim, facts.Namespace, err = r.claimFacts(ctx, pv)
+	if err != nil {
+		return facts, err
+	}
+	if p.optOut(pv, facts) != "" {
+		return facts, nil
+	}
+
+	_, resolveSpan := r.startSpan(ctx, "resolveNode", pvAttributes(pv)...)
+	node := p.node(pv)
+	resolveSpan.SetAttributes(attrNode.String(node))
+	resolveSpan.End()
+	if node == "" {
+		return facts, nil
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attrNode.String(node))
+
+	lookupCtx, lookupSpan := r.startSpan(ctx, "getNode", attrNode.String(node))
+	nodeMeta := newMetadata(nodeGVK)
+	err = r.Client.Get(lookupCtx, client.ObjectKey{Name: node}, nodeMeta)
+	// only a NotFound proves the node is gone, any other error must not lead to a deletion
+	if client.IgnoreNotFound(err) != nil {
+		endSpan(lookupSpan, err)
+		return facts, err
+	}
+	facts.NodeFound = err == nil
+	if facts.NodeFound {
+		facts.Node = &nodeMeta.ObjectMeta
+	} else {
+		facts.LastNodeLabels = r.nodeLabels().get(node)
+	}
+	lookupSpan.SetAttributes(attribute.Bool("node.found", facts.NodeFound))
+	endSpan(lookupSpan, nil)
+
+	return facts, nil
+}
+
+// baseFacts returns the facts about a PV that need no API call
+func (r *PVCleanupController) baseFacts(pv corev1.PersistentVolume) Facts {
+	facts := Facts{Now: r.now()}
+	if since, ok := r.tracker().since(pv.Name); ok {
+		facts.TrackedSince = since
+	}
+	return facts
+}
+
+// orphanedSince returns when the PV was first found orphaned from the state tracker, falling back to the current time
+func (r *PVCleanupController) orphanedSince(pv corev1.PersistentVolume) time.Time {
+	return r.baseFacts(pv).orphanedSince()
+}
+
+// claimFacts returns the metadata of the claim of the PV and of its namespace, nil for the ones that are gone.
+// A claim whose UID differs from the claimRef of the PV is gone too.
+func (r *PVCleanupController) claimFacts(ctx context.Context,
+	pv corev1.PersistentVolume) (*metav1.ObjectMeta, *metav1.ObjectMeta, error) {
+	claim := pv.Spec.ClaimRef
+	if claim == nil {
+		return nil, nil, nil
+	}
+
+	var claimMeta, namespaceMeta *metav1.ObjectMeta
+	pvc := newMetadata(pvcGVK)
+	err := r.Client.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: claim.Name}, pvc)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, nil, err
+	}
+	// a claim recreated under the same name is not the claim of the PV, it is treated like a deleted one
+	if err == nil && (claim.UID == "" || claim.UID == pvc.UID) {
+		claimMeta = &pvc.ObjectMeta
+	}
+
+	ns := newMetadata(namespaceGVK)
+	err = r.Client.Get(ctx, client.ObjectKey{Name: claim.Namespace}, ns)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, nil, err
+	}
+	if err == nil {
+		namespaceMeta = &ns.ObjectMeta
+	}
+
+	return claimMeta, namespaceMeta, nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPVCleanupController_Assess(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	withAnnotation := func(pv *corev1.PersistentVolume, key, value string) *corev1.PersistentVolume {
+		if pv.Annotations == nil {
+			pv.Annotations = map[string]string{}
+		}
+		pv.Annotations[key] = value
+		return pv
+	}
+	released := func(pv *corev1.PersistentVolume, at string) *corev1.PersistentVolume {
+		pv.Status.Phase = corev1.VolumeReleased
+		return withAnnotation(pv, ReleasedAtAnnotation, at)
+	}
+
+	var tests = []struct {
+		name string
+		pv   *corev1.PersistentVolume
+		want Decision
+	}{
+		{
+			name: "Storage class not managed",
+			pv:   newLocalPV("pv-1", "openebs", "node-gone"),
+			want: Decision{PV: "pv-1", StorageClass: "openebs", Verdict: VerdictSkip, Reason: ReasonStorageClass},
+		},
+		{
+			name: "Opted out",
+			pv:   withAnnotation(newLocalPV("pv-1", "topolvm", "node-gone"), SkipAnnotation, "true"),
+			want: Decision{PV: "pv-1", StorageClass: "topolvm", Verdict: VerdictSkip, Reason: ReasonPVAnnotation},
+		},
+		{
+			name: "Node exists",
+			pv:   newLocalPV("pv-1", "topolvm", "node-01"),
+			want: Decision{PV: "pv-1", StorageClass: "topolvm", Node: "node-01", NodeStatus: NodeStatusFound,
+				Verdict: VerdictKeep, Reason: ReasonNodeExists},
+		},
+		{
+			name: "Orphaned PV",
+			pv:   newLocalPV("pv-1", "topolvm", "node-gone"),
+			want: Decision{PV: "pv-1", StorageClass: "topolvm", Node: "node-gone", NodeStatus: NodeStatusMissing,
+				Verdict: VerdictDelete, Reason: ReasonOrphaned},
+		},
+		{
+			name: "Orphaned PV held by a guard",
+			pv: withAnnotation(newLocalPV("pv-1", "topolvm", "node-gone"), RetainUntilAnnotation,
+				"2025-03-02T00:00:00Z"),
+			want: Decision{PV: "pv-1", StorageClass: "topolvm", Node: "node-gone", NodeStatus: NodeStatusMissing,
+				Verdict: VerdictHold, Reason: ReasonRetentionHold, Remaining: 12 * time.Hour,
+				Message: "PV is retained until 2025-03-02T00:00:00Z by PersistentVolume pv-1"},
+		},
+		{
+			name: "Released PV within TTL",
+			pv:   released(newLocalPV("pv-1", "topolvm", "node-01"), "2025-03-01T11:00:00Z"),
+			want: Decision{PV: "pv-1", StorageClass: "topolvm", Node: "node-01", NodeStatus: NodeStatusFound,
+				Verdict: VerdictGrace, Reason: ReasonReleasedTTL, Remaining: 23 * time.Hour,
+				Message: "Released TTL ends in 23h0m0s"},
+		},
+		{
+			name: "Released PV past TTL",
+			pv:   released(newLocalPV("pv-1", "topolvm", "node-01"), "2025-02-27T11:00:00Z"),
+			want: Decision{PV: "pv-1", StorageClass: "topolvm", Node: "node-01", NodeStatus: NodeStatusFound,
+				Verdict: VerdictDelete, Reason: ReasonReleasedTTL},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fakeClient := crFake.NewClientBuilder().WithScheme(s).
+				WithObjects(tt.pv, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-01"}}).Build()
+			r := &PVCleanupController{
+				Client:            fakeClient,
+				NodeSelectorKeys:  []string{testNodeSelectorKey},
+				StorageClassNames: []string{"topolvm"},
+				ReleasedTTL:       24 * time.Hour,
+				Clock:             clocktesting.NewFakePassiveClock(now),
+			}
+
+			got, err := r.Assess(ctx, *tt.pv)
+			require.NoError(t, err)
+			// the evidence is covered by the policy tests
+			got.Evidence = nil
+			assert.Equal(t, tt.want, got)
+
+			var pv corev1.PersistentVolume
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: tt.pv.Name}, &pv))
+			assert.Equal(t, tt.pv.Annotations, pv.Annotations, "Expected the PV to be left untouched")
+			assert.Zero(t, r.tracker().len(), "Expected no state to be tracked")
+		})
+	}
+}
+
+func TestPVCleanupController_Assess_nodeLookupError(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+
+	ctx := context.Background()
+	pv := newLocalPV("pv-1", "topolvm", "node-01")
+	fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(pv).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object,
+				opts ...client.GetOption) error {
+				if obj.GetObjectKind().GroupVersionKind() == nodeGVK {
+					return apierrors.NewInternalError(errors.New("etcd unavailable"))
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).Build()
+	r := &PVCleanupController{
+		Client:            fakeClient,
+		NodeSelectorKeys:  []string{testNodeSelectorKey},
+		StorageClassNames: []string{"topolvm"},
+	}
+
+	// a failed lookup is not a missing node
+	_, err := r.Assess(ctx, *pv)
+	assert.True(t, apierrors.IsInternalError(err), "Expected the node lookup error, got %v", err)
+
+	_, outcome, err := r.reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: pv.Name}})
+	assert.Error(t, err)
+	assert.Equal(t, outcomeError, outcome)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(pv), &corev1.PersistentVolume{}))
+}
+
+func TestPVCleanupController_claimFacts(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+
+	var tests = []struct {
+		name      string
+		claimUID  types.UID
+		wantClaim bool
+	}{
+		{name: "Claim of the PV", claimUID: "pvc-uid", wantClaim: true},
+		{name: "ClaimRef without UID", wantClaim: true},
+		{name: "Claim recreated under the same name", claimUID: "old-pvc-uid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pv := newLocalPV("pv-1", "topolvm", "node-gone")
+			pv.Spec.ClaimRef = &corev1.ObjectReference{Namespace: "team-a", Name: "data", UID: tt.claimUID}
+			pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "data",
+				UID: "pvc-uid", Annotations: map[string]string{SkipAnnotation: "true"}}}
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+			r := &PVCleanupController{Client: crFake.NewClientBuilder().WithScheme(s).WithObjects(pvc, namespace).Build()}
+
+			claim, ns, err := r.claimFacts(context.Background(), *pv)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantClaim, claim != nil)
+			require.NotNil(t, ns, "Expected the namespace of the claimRef to be looked up")
+			assert.Equal(t, "team-a", ns.Name)
+		})
+	}
+}
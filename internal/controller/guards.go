@@ -0,0 +1,108 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Names of the guards, used as the guard label of the guard trips metric
+const (
+	guardPaused            = string(ReasonPaused)
+	guardRetentionHold     = string(ReasonRetentionHold)
+	guardApproval          = string(ReasonApproval)
+	guardMaintenanceWindow = string(ReasonMaintenanceWindow)
+	guardPolicyWebhook     = string(ReasonPolicyWebhook)
+)
+
+// guardTrip describes a guard that blocked the deletion of a PV
+type guardTrip struct {
+	// Guard is the name of the guard that tripped
+	Guard string
+	// Message is a human readable explanation, used in logs and events
+	Message string
+	// Warning marks trips caused by a misconfiguration rather than a deliberate hold
+	Warning bool
+	// RequeueAfter is when the guard should be evaluated again
+	RequeueAfter time.Duration
+}
+
+// checkGuards runs the guards protecting PV deletion against fresh facts and returns the first one that tripped,
+// if any. It runs right before the deletion since the facts may have changed since the PV was evaluated.
+func (r *PVCleanupController) checkGuards(ctx context.Context, pv corev1.PersistentVolume, cause deletionCause,
+	action orphanAction) (*guardTrip, error) {
+	ctx, span := r.startSpan(ctx, "checkGuards", pvAttributes(pv)...)
+	claim, namespace, err := r.claimFacts(ctx, pv)
+	if err != nil {
+		endSpan(span, err)
+		return nil, err
+	}
+
+	trip, err := r.pauseHold(ctx)
+	if err != nil {
+		endSpan(span, err)
+		return nil, err
+	}
+	if trip == nil {
+		trip = retentionHold(pv, Facts{Now: r.now(), Claim: claim, Namespace: namespace})
+	}
+	if trip == nil {
+		if trip, err = r.approvalGuard(ctx, pv, cause, action); err != nil {
+			endSpan(span, err)
+			return nil, err
+		}
+	}
+	if trip == nil {
+		if trip = r.policy().maintenanceHold(r.now()); trip != nil {
+			trip.RequeueAfter += maintenanceWindowRequeueDelay
+		}
+	}
+	if trip == nil {
+		if trip, err = r.reviewDeletion(ctx, pv, cause, action); err != nil {
+			endSpan(span, err)
+			return nil, err
+		}
+	}
+	if trip != nil {
+		if trip.RequeueAfter <= 0 {
+			trip.RequeueAfter = r.RequeueDuration
+		}
+		span.SetAttributes(attrGuard.String(trip.Guard))
+	}
+	endSpan(span, nil)
+
+	return trip, nil
+}
+
+// now returns the current time of the controller clock
+func (r *PVCleanupController) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
+
+// event records an event on the given object if an event recorder is configured
+func (r *PVCleanupController) event(pv *corev1.PersistentVolume, eventType, reason, message string) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Event(pv, eventType, reason, message)
+}
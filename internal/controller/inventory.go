@@ -0,0 +1,159 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/strings/slices"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// inventoryScrapeTimeout bounds the cache reads of a scrape
+	inventoryScrapeTimeout = 10 * time.Second
+	// inventoryOrphanedGroup is the node_group of PVs whose node no longer exists
+	inventoryOrphanedGroup = "orphaned"
+)
+
+// inventoryKey identifies a series of the inventory metrics
+type inventoryKey struct {
+	node         string
+	storageClass string
+	managed      bool
+}
+
+// inventoryValue is the aggregated value of a series of the inventory metrics
+type inventoryValue struct {
+	count    int
+	capacity int64
+}
+
+// inventoryCollector reports the local PVs and their capacity per node and storage class.
+// The values are computed from the cache on every scrape. When a node label is set, the PVs are aggregated
+// by the value of that label on their node instead of by node name, to keep the cardinality in check.
+type inventoryCollector struct {
+	reader            client.Reader
+	nodeSelectorKeys  []string
+	storageClassNames []string
+	nodeLabel         string
+
+	pvs      *prometheus.Desc
+	capacity *prometheus.Desc
+}
+
+// newInventoryCollector returns an inventoryCollector reading from the given reader, usually the manager cache
+func newInventoryCollector(reader client.Reader, nodeSelectorKeys, storageClassNames []string,
+	nodeLabel string) *inventoryCollector {
+	nodeLabelName := "node"
+	if nodeLabel != "" {
+		nodeLabelName = "node_group"
+	}
+	labelNames := []string{nodeLabelName, "storage_class", "managed"}
+
+	return &inventoryCollector{
+		reader:            reader,
+		nodeSelectorKeys:  nodeSelectorKeys,
+		storageClassNames: storageClassNames,
+		nodeLabel:         nodeLabel,
+		pvs: prometheus.NewDesc("local_pv_cleaner_node_pvs",
+			"Number of local PVs per node and storage class", labelNames, nil),
+		capacity: prometheus.NewDesc("local_pv_cleaner_node_pv_capacity_bytes",
+			"Total capacity of the local PVs per node and storage class", labelNames, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *inventoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.pvs
+	ch <- c.capacity
+}
+
+// Collect implements prometheus.Collector
+func (c *inventoryCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), inventoryScrapeTimeout)
+	defer cancel()
+
+	inventory, err := c.inventory(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to compute the local PV inventory")
+		ch <- prometheus.NewInvalidMetric(c.pvs, err)
+		return
+	}
+
+	for key, value := range inventory {
+		labels := []string{key.node, key.storageClass, strconv.FormatBool(key.managed)}
+		ch <- prometheus.MustNewConstMetric(c.pvs, prometheus.GaugeValue, float64(value.count), labels...)
+		ch <- prometheus.MustNewConstMetric(c.capacity, prometheus.GaugeValue, float64(value.capacity), labels...)
+	}
+}
+
+// inventory aggregates the local PVs by node, or node group, and storage class
+func (c *inventoryCollector) inventory(ctx context.Context) (map[inventoryKey]inventoryValue, error) {
+	var pvList corev1.PersistentVolumeList
+	if err := c.reader.List(ctx, &pvList); err != nil {
+		return nil, err
+	}
+
+	var nodeLabels map[string]map[string]string
+	if c.nodeLabel != "" {
+		nodeList := newMetadataList(nodeGVK)
+		if err := c.reader.List(ctx, nodeList); err != nil {
+			return nil, err
+		}
+		nodeLabels = make(map[string]map[string]string, len(nodeList.Items))
+		for _, node := range nodeList.Items {
+			nodeLabels[node.Name] = node.Labels
+		}
+	}
+
+	inventory := map[inventoryKey]inventoryValue{}
+	for _, pv := range pvList.Items {
+		node := getNodeNameFromAffinity(pv.Spec.NodeAffinity, c.nodeSelectorKeys)
+		if node == "" {
+			continue
+		}
+		if c.nodeLabel != "" {
+			labels, ok := nodeLabels[node]
+			if !ok {
+				node = inventoryOrphanedGroup
+			} else {
+				node = labels[c.nodeLabel]
+			}
+		}
+
+		key := inventoryKey{
+			node:         node,
+			storageClass: pv.Spec.StorageClassName,
+			managed: len(c.storageClassNames) == 0 ||
+				slices.Contains(c.storageClassNames, pv.Spec.StorageClassName),
+		}
+		value := inventory[key]
+		value.count++
+		if storage, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok {
+			value.capacity += storage.Value()
+		}
+		inventory[key] = value
+	}
+
+	return inventory, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestInventoryCollector(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+
+	newPV := func(name, storageClass, node, size string) *corev1.PersistentVolume {
+		pv := newLocalPV(name, storageClass, node)
+		pv.Spec.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)}
+		return pv
+	}
+	newNode := func(name, instanceType string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Labels: map[string]string{"node.kubernetes.io/instance-type": instanceType},
+		}}
+	}
+	objects := []client.Object{
+		newNode("node-01", "m5.large"),
+		newNode("node-02", "m5.large"),
+		newPV("pv-1", "topolvm", "node-01", "1Gi"),
+		newPV("pv-2", "topolvm", "node-01", "2Gi"),
+		newPV("pv-3", "topolvm", "node-02", "4Gi"),
+		newPV("pv-4", "openebs", "node-02", "8Gi"),
+		newPV("pv-5", "topolvm", "node-gone", "16Gi"),
+		&corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv-no-affinity"}},
+	}
+	fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build()
+
+	var tests = []struct {
+		name      string
+		nodeLabel string
+		want      string
+	}{
+		{
+			name: "Per node",
+			want: `
+# HELP local_pv_cleaner_node_pv_capacity_bytes Total capacity of the local PVs per node and storage class
+# TYPE local_pv_cleaner_node_pv_capacity_bytes gauge
+local_pv_cleaner_node_pv_capacity_bytes{managed="false",node="node-02",storage_class="openebs"} 8.589934592e+09
+local_pv_cleaner_node_pv_capacity_bytes{managed="true",node="node-01",storage_class="topolvm"} 3.221225472e+09
+local_pv_cleaner_node_pv_capacity_bytes{managed="true",node="node-02",storage_class="topolvm"} 4.294967296e+09
+local_pv_cleaner_node_pv_capacity_bytes{managed="true",node="node-gone",storage_class="topolvm"} 1.7179869184e+10
+# HELP local_pv_cleaner_node_pvs Number of local PVs per node and storage class
+# TYPE local_pv_cleaner_node_pvs gauge
+local_pv_cleaner_node_pvs{managed="false",node="node-02",storage_class="openebs"} 1
+local_pv_cleaner_node_pvs{managed="true",node="node-01",storage_class="topolvm"} 2
+local_pv_cleaner_node_pvs{managed="true",node="node-02",storage_class="topolvm"} 1
+local_pv_cleaner_node_pvs{managed="true",node="node-gone",storage_class="topolvm"} 1
+`,
+		},
+		{
+			name:      "Aggregated by node label",
+			nodeLabel: "node.kubernetes.io/instance-type",
+			want: `
+# HELP local_pv_cleaner_node_pvs Number of local PVs per node and storage class
+# TYPE local_pv_cleaner_node_pvs gauge
+local_pv_cleaner_node_pvs{managed="false",node_group="m5.large",storage_class="openebs"} 1
+local_pv_cleaner_node_pvs{managed="true",node_group="m5.large",storage_class="topolvm"} 3
+local_pv_cleaner_node_pvs{managed="true",node_group="orphaned",storage_class="topolvm"} 1
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := newInventoryCollector(fakeClient, []string{testNodeSelectorKey}, []string{"topolvm"},
+				tt.nodeLabel)
+			names := []string{"local_pv_cleaner_node_pvs"}
+			if tt.nodeLabel == "" {
+				names = append(names, "local_pv_cleaner_node_pv_capacity_bytes")
+			}
+			assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(tt.want), names...))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// maintenanceWindowRequeueDelay delays the requeue of the PVs held outside the maintenance windows past the
+// opening of the next window, so that the windowOpener enqueues the oldest orphans first
+const maintenanceWindowRequeueDelay = 30 * time.Second
+
+// maintenanceHold trips when no maintenance window is open at the given time
+func (p Policy) maintenanceHold(now time.Time) *guardTrip {
+	if p.MaintenanceWindows.Open(now) {
+		return nil
+	}
+
+	next := p.MaintenanceWindows.Next(now)
+	if next.IsZero() {
+		return &guardTrip{
+			Guard:   guardMaintenanceWindow,
+			Message: "Outside the maintenance windows, no window opens anymore",
+			Warning: true,
+		}
+	}
+	return &guardTrip{
+		Guard:        guardMaintenanceWindow,
+		Message:      fmt.Sprintf("Outside the maintenance windows, the next window opens at %s", next.Format(time.RFC3339)),
+		RequeueAfter: next.Sub(now),
+	}
+}
+
+// nextMaintenanceWindow returns when the next maintenance window opens, nil while a window is open
+func (r *PVCleanupController) nextMaintenanceWindow() *metav1.Time {
+	now := r.now()
+	if r.MaintenanceWindows.Open(now) {
+		return nil
+	}
+	next := r.MaintenanceWindows.Next(now)
+	if next.IsZero() {
+		return nil
+	}
+	return &metav1.Time{Time: next}
+}
+
+// windowOpener enqueues the PVs held outside the maintenance windows once a window opens, oldest orphans first
+type windowOpener struct {
+	controller *PVCleanupController
+	events     chan<- event.GenericEvent
+}
+
+// Start waits for the openings of the windows until the context is cancelled
+func (o *windowOpener) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	for {
+		next := o.controller.MaintenanceWindows.Next(o.controller.now())
+		if next.IsZero() {
+			logger.Info("No maintenance window opens anymore")
+			return nil
+		}
+
+		timer := time.NewTimer(next.Sub(o.controller.now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		held := o.controller.tracker().oldestFirst(guardMaintenanceWindow)
+		logger.Info("Maintenance window opened", "held", len(held))
+		for _, name := range held {
+			select {
+			case o.events <- event.GenericEvent{Object: &corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: name}}}:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+// NeedLeaderElection makes sure only the leader enqueues the held PVs
+func (o *windowOpener) NeedLeaderElection() bool {
+	return true
+}
+
+// sortOldestOrphanFirst sorts the PVs by the time they were first found orphaned
+func (r *PVCleanupController) sortOldestOrphanFirst(pvs []corev1.PersistentVolume) {
+	since := make(map[string]time.Time, len(pvs))
+	for _, pv := range pvs {
+		since[pv.Name] = r.orphanedSince(pv)
+	}
+	sort.SliceStable(pvs, func(i, j int) bool {
+		return since[pvs[i].Name].Before(since[pvs[j].Name])
+	})
+}
+
+// newMaintenanceWindowMetrics returns the gauges reporting the maintenance windows, computed on every scrape
+func newMaintenanceWindowMetrics(r *PVCleanupController) []prometheus.Collector {
+	return []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "local_pv_cleaner_maintenance_window_open",
+			Help: "Whether a maintenance window is open and PVs may be deleted",
+		}, func() float64 {
+			if r.MaintenanceWindows.Open(r.now()) {
+				return 1
+			}
+			return 0
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "local_pv_cleaner_maintenance_window_next_open_timestamp_seconds",
+			Help: "Unix time the next maintenance window opens, 0 while a window is open",
+		}, func() float64 {
+			if next := r.nextMaintenanceWindow(); next != nil {
+				return float64(next.Unix())
+			}
+			return 0
+		}),
+	}
+}
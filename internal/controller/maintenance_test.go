@@ -0,0 +1,154 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/window"
+)
+
+func TestPolicy_Evaluate_maintenanceWindows(t *testing.T) {
+	windows, err := window.ParseSchedule([]string{"Mon-Fri 09:00-17:00"})
+	require.NoError(t, err)
+	policy := Policy{NodeSelectorKeys: []string{testNodeSelectorKey}, MaintenanceWindows: windows}
+	pv := *newLocalPV("pv-1", "topolvm", "node-gone")
+
+	// Saturday
+	d := policy.Evaluate(pv, Facts{Now: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)})
+	assert.Equal(t, VerdictHold, d.Verdict)
+	assert.Equal(t, ReasonMaintenanceWindow, d.Reason)
+	assert.Equal(t, 45*time.Hour, d.Remaining)
+	assert.Equal(t, "Outside the maintenance windows, the next window opens at 2025-03-03T09:00:00Z", d.Message)
+
+	// Monday
+	d = policy.Evaluate(pv, Facts{Now: time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)})
+	assert.Equal(t, VerdictDelete, d.Verdict)
+
+	// the retention hold is reported first
+	pv.Annotations = map[string]string{RetainUntilAnnotation: "2025-03-02T00:00:00Z"}
+	d = policy.Evaluate(pv, Facts{Now: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)})
+	assert.Equal(t, ReasonRetentionHold, d.Reason)
+}
+
+func TestPVCleanupController_deleteOrphanedPV_maintenanceWindow(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+
+	ctx := context.Background()
+	windows, err := window.ParseSchedule([]string{"Mon-Fri 09:00-17:00"})
+	require.NoError(t, err)
+	pv := newLocalPV("pv-1", "topolvm", "node-gone")
+	fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(pv).
+		WithIndex(&storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV).Build()
+	fakeClock := clocktesting.NewFakePassiveClock(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	recorder := record.NewFakeRecorder(1)
+	r := &PVCleanupController{
+		Client:             fakeClient,
+		NodeSelectorKeys:   []string{testNodeSelectorKey},
+		MaintenanceWindows: windows,
+		Recorder:           recorder,
+		Clock:              fakeClock,
+	}
+
+	// outside the windows the PV is held until shortly after the next window opens
+	result, outcome, err := r.deleteOrphanedPV(ctx, *pv)
+	require.NoError(t, err)
+	assert.Equal(t, outcomeHold, outcome)
+	assert.Equal(t, 45*time.Hour+maintenanceWindowRequeueDelay, result.RequeueAfter)
+	assert.Contains(t, <-recorder.Events, "the next window opens at 2025-03-03T09:00:00Z")
+	assert.Equal(t, []string{"pv-1"}, r.tracker().oldestFirst(guardMaintenanceWindow))
+	assert.NoError(t, testutil.CollectAndCompare(newStateCollector(r.tracker()), strings.NewReader(`
+# HELP local_pv_cleaner_orphaned_pvs Number of Orphaned PVs that are not deleted yet
+# TYPE local_pv_cleaner_orphaned_pvs gauge
+local_pv_cleaner_orphaned_pvs{reason="maintenance-window",storage_class="topolvm"} 1
+`), "local_pv_cleaner_orphaned_pvs"))
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(pv), &corev1.PersistentVolume{}))
+
+	// inside a window the PV is deleted
+	fakeClock.SetTime(time.Date(2025, 3, 3, 9, 0, 30, 0, time.UTC))
+	_, outcome, err = r.deleteOrphanedPV(ctx, *pv)
+	require.NoError(t, err)
+	assert.Equal(t, outcomeDelete, outcome)
+	assert.Empty(t, r.tracker().oldestFirst(guardMaintenanceWindow))
+}
+
+func TestWindowOpener(t *testing.T) {
+	windows, err := window.ParseSchedule([]string{"Mon-Fri 09:00-17:00"})
+	require.NoError(t, err)
+	now := time.Date(2025, 3, 3, 8, 59, 59, 990000000, time.UTC)
+	r := &PVCleanupController{MaintenanceWindows: windows, Clock: clocktesting.NewFakePassiveClock(now)}
+	for name, since := range map[string]time.Duration{"pv-young": time.Hour, "pv-old": 48 * time.Hour,
+		"pv-middle": 24 * time.Hour} {
+		state := newPVState(corev1.PersistentVolume{}, guardMaintenanceWindow, true, false)
+		state.since = now.Add(-since)
+		r.tracker().set(name, state, now)
+	}
+	r.tracker().set("pv-retained", newPVState(corev1.PersistentVolume{}, guardRetentionHold, true, false), now)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan event.GenericEvent)
+	go func() {
+		_ = (&windowOpener{controller: r, events: events}).Start(ctx)
+	}()
+
+	var names []string
+	for range 3 {
+		select {
+		case e := <-events:
+			names = append(names, e.Object.GetName())
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected the held PVs to be enqueued once the window opens")
+		}
+	}
+	assert.Equal(t, []string{"pv-old", "pv-middle", "pv-young"}, names)
+}
+
+func TestPVCleanupController_sortOldestOrphanFirst(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	r := &PVCleanupController{Clock: clocktesting.NewFakePassiveClock(now)}
+	var pvs []corev1.PersistentVolume
+	for name, since := range map[string]time.Duration{"pv-young": time.Hour, "pv-old": 2 * time.Hour} {
+		pv := newLocalPV(name, "topolvm", "node-gone")
+		state := newPVState(*pv, guardMaintenanceWindow, true, false)
+		state.since = now.Add(-since)
+		r.tracker().set(name, state, now)
+		pvs = append(pvs, *pv)
+	}
+
+	pvs = append([]corev1.PersistentVolume{*newLocalPV("pv-new", "topolvm", "node-gone")}, pvs...)
+	r.sortOldestOrphanFirst(pvs)
+	assert.Equal(t, "pv-old", pvs[0].Name)
+	assert.Equal(t, "pv-young", pvs[1].Name)
+	assert.Equal(t, "pv-new", pvs[2].Name)
+}
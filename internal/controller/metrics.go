@@ -0,0 +1,178 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	deletedPVsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "local_pv_cleaner_deleted_pvs_total",
+			Help: "Total number of Orphaned PVs deleted",
+		},
+		[]string{"storage_class"},
+	)
+	deletedVolumeAttachmentsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "local_pv_cleaner_deleted_volume_attachments_total",
+			Help: "Total number of stale VolumeAttachments deleted for Orphaned PVs",
+		},
+		[]string{"storage_class"},
+	)
+	skippedPVsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "local_pv_cleaner_skipped_pvs_total",
+			Help: "Total number of PVs skipped by the cleaner",
+		},
+		[]string{"storage_class", "reason"},
+	)
+	guardTripsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "local_pv_cleaner_guard_trips_total",
+			Help: "Total number of Orphaned PV deletions blocked by a guard",
+		},
+		[]string{"storage_class", "guard"},
+	)
+	strippedFinalizersTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "local_pv_cleaner_stripped_finalizers_total",
+			Help: "Total number of external-provisioner finalizers stripped from Orphaned PVs",
+		},
+		[]string{"storage_class"},
+	)
+	collectedReleasedPVsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "local_pv_cleaner_collected_released_pvs_total",
+			Help: "Total number of PVs deleted after staying Released longer than the TTL",
+		},
+		[]string{"storage_class"},
+	)
+	sweepDurationSeconds = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "local_pv_cleaner_sweep_duration_seconds",
+			Help:    "Duration of the periodic sweeps over all PVs",
+			Buckets: prometheus.ExponentialBuckets(0.01, 2, 12),
+		},
+	)
+	sweepCandidates = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "local_pv_cleaner_sweep_candidates",
+			Help: "Number of orphan candidates found by the last sweep",
+		},
+	)
+	mutationWaitSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "local_pv_cleaner_mutation_wait_seconds",
+			Help:    "Time API mutating operations waited for the mutation budget",
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+		},
+		[]string{"operation"},
+	)
+	dryRunWouldDeleteTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "local_pv_cleaner_dry_run_would_delete_total",
+			Help: "Total number of PV deletions skipped because of the DryRun",
+		},
+		[]string{"storage_class", "cause"},
+	)
+	deleteFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "local_pv_cleaner_delete_failures_total",
+			Help: "Total number of failed PV deletions",
+		},
+		[]string{"storage_class", "error_class"},
+	)
+	orphanDeletionLatencySeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "local_pv_cleaner_orphan_deletion_latency_seconds",
+			Help:    "Time from the node loss being detected to the deletion of the Orphaned PV",
+			Buckets: prometheus.ExponentialBuckets(1, 4, 12),
+		},
+		[]string{"storage_class"},
+	)
+	reconcileDecisionSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "local_pv_cleaner_reconcile_decision_duration_seconds",
+			Help:    "Duration of the PV reconciles by decision",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 4, 10),
+		},
+		[]string{"decision"},
+	)
+	auditFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "local_pv_cleaner_audit_failures_total",
+			Help: "Total number of audit records that could not be written",
+		},
+		[]string{"action"},
+	)
+	deletionsPaused = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "local_pv_cleaner_paused",
+			Help: "Whether the deletions are paused by the pause ConfigMap",
+		},
+	)
+	orphanedVolumeTransitionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "local_pv_cleaner_orphaned_volume_transitions_total",
+			Help: "Total number of OrphanedVolume phase transitions by the phase entered",
+		},
+		[]string{"phase"},
+	)
+	backupFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "local_pv_cleaner_backup_failures_total",
+			Help: "Total number of PV deletions held back because the PV could not be backed up",
+		},
+		[]string{"storage_class"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(deletedPVsTotal, deletedVolumeAttachmentsTotal, skippedPVsTotal, guardTripsTotal,
+		strippedFinalizersTotal, collectedReleasedPVsTotal, sweepDurationSeconds, sweepCandidates, mutationWaitSeconds,
+		dryRunWouldDeleteTotal, deleteFailuresTotal, orphanDeletionLatencySeconds, reconcileDecisionSeconds,
+		auditFailuresTotal, backupFailuresTotal, orphanedVolumeTransitionsTotal, deletionsPaused)
+}
+
+// errorClass classifies an API error for the error_class label of the delete failures metric
+func errorClass(err error) string {
+	switch {
+	case apierrors.IsNotFound(err):
+		return "not_found"
+	case apierrors.IsConflict(err):
+		return "conflict"
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return "forbidden"
+	case apierrors.IsTooManyRequests(err):
+		return "throttled"
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case apierrors.IsInternalError(err), apierrors.IsServiceUnavailable(err):
+		return "server_error"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestErrorClass(t *testing.T) {
+	pvResource := schema.GroupResource{Resource: "persistentvolumes"}
+
+	var tests = []struct {
+		err  error
+		want string
+	}{
+		{apierrors.NewNotFound(pvResource, "pv-1"), "not_found"},
+		{apierrors.NewConflict(pvResource, "pv-1", errors.New("conflict")), "conflict"},
+		{apierrors.NewForbidden(pvResource, "pv-1", errors.New("forbidden")), "forbidden"},
+		{apierrors.NewTooManyRequests("slow down", 1), "throttled"},
+		{apierrors.NewTimeoutError("timeout", 1), "timeout"},
+		{fmt.Errorf("waiting for budget: %w", context.DeadlineExceeded), "timeout"},
+		{context.Canceled, "canceled"},
+		{apierrors.NewInternalError(errors.New("boom")), "server_error"},
+		{errors.New("boom"), "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, errorClass(tt.err))
+		})
+	}
+}
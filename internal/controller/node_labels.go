@@ -0,0 +1,106 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+// deletedNodeLabelsRetention is how long the labels of a deleted node are kept for the expressions
+const deletedNodeLabelsRetention = 7 * 24 * time.Hour
+
+// deletedNode is what is remembered of a deleted node
+type deletedNode struct {
+	labels    map[string]string
+	deletedAt time.Time
+}
+
+// nodeLabelRecorder remembers the labels last seen on the deleted nodes, so that the expressions can still read
+// them once the PV is orphaned. It only lives in memory, the labels of the nodes deleted while the controller was
+// not running are unknown.
+type nodeLabelRecorder struct {
+	mu      sync.Mutex
+	deleted map[string]deletedNode
+}
+
+// newNodeLabelRecorder returns an empty nodeLabelRecorder
+func newNodeLabelRecorder() *nodeLabelRecorder {
+	return &nodeLabelRecorder{deleted: map[string]deletedNode{}}
+}
+
+// record remembers the labels of a deleted node and forgets the nodes deleted longer than the retention ago
+func (n *nodeLabelRecorder) record(name string, labels map[string]string, now time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for deletedName, node := range n.deleted {
+		if now.Sub(node.deletedAt) > deletedNodeLabelsRetention {
+			delete(n.deleted, deletedName)
+		}
+	}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	n.deleted[name] = deletedNode{labels: labels, deletedAt: now}
+}
+
+// forget drops the labels of a node that came back
+func (n *nodeLabelRecorder) forget(name string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	delete(n.deleted, name)
+}
+
+// get returns the labels last seen on a deleted node, nil when they are unknown
+func (n *nodeLabelRecorder) get(name string) map[string]string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.deleted[name].labels
+}
+
+// nodeLabels returns the node label recorder of the controller
+func (r *PVCleanupController) nodeLabels() *nodeLabelRecorder {
+	r.nodeLabelsOnce.Do(func() {
+		r.deletedNodes = newNodeLabelRecorder()
+	})
+	return r.deletedNodes
+}
+
+// nodeLabelsEventHandler records the labels of the nodes as they get deleted
+func (r *PVCleanupController) nodeLabelsEventHandler() toolscache.ResourceEventHandler {
+	return toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			if node, ok := obj.(metav1.Object); ok {
+				r.nodeLabels().forget(node.GetName())
+			}
+		},
+		DeleteFunc: func(obj any) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if node, ok := obj.(metav1.Object); ok {
+				r.nodeLabels().record(node.GetName(), node.GetLabels(), r.now())
+			}
+		},
+	}
+}
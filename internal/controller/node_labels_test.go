@@ -0,0 +1,58 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestPVCleanupController_nodeLabelsEventHandler(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	fakeClock := clocktesting.NewFakePassiveClock(now)
+	r := &PVCleanupController{Clock: fakeClock}
+	handler := r.nodeLabelsEventHandler()
+	newNode := func(name string) *metav1.PartialObjectMetadata {
+		node := newMetadata(nodeGVK)
+		node.Name = name
+		node.Labels = map[string]string{"lifecycle": "spot"}
+		return node
+	}
+
+	handler.OnAdd(newNode("node-01"), true)
+	assert.Nil(t, r.nodeLabels().get("node-01"), "Expected no labels recorded for a live node")
+
+	handler.OnDelete(newNode("node-01"))
+	handler.OnDelete(toolscache.DeletedFinalStateUnknown{Key: "node-02", Obj: newNode("node-02")})
+	assert.Equal(t, map[string]string{"lifecycle": "spot"}, r.nodeLabels().get("node-01"))
+	assert.Equal(t, map[string]string{"lifecycle": "spot"}, r.nodeLabels().get("node-02"))
+
+	// a node that comes back is live again
+	handler.OnAdd(newNode("node-02"), false)
+	assert.Nil(t, r.nodeLabels().get("node-02"))
+
+	// the labels of nodes deleted long ago are dropped with the next deletion
+	fakeClock.SetTime(now.Add(deletedNodeLabelsRetention + time.Minute))
+	handler.OnDelete(newNode("node-03"))
+	assert.Nil(t, r.nodeLabels().get("node-01"))
+	assert.NotNil(t, r.nodeLabels().get("node-03"))
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/cloudevents"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/notify"
+)
+
+// notify queues a notification about the PV if a notifier is configured
+func (r *PVCleanupController) notify(pv corev1.PersistentVolume, kind notify.Kind, message string) {
+	if r.Notifier == nil {
+		return
+	}
+
+	event := notify.Event{
+		Time:         r.now().UTC(),
+		Kind:         kind,
+		PV:           pv.Name,
+		StorageClass: pv.Spec.StorageClassName,
+		Node:         getNodeNameFromAffinity(pv.Spec.NodeAffinity, r.NodeSelectorKeys),
+		Message:      message,
+	}
+	if claim := pv.Spec.ClaimRef; claim != nil {
+		event.Claim = claim.Namespace + "/" + claim.Name
+	}
+	r.Notifier.Notify(event)
+}
+
+// reportOrphanFound notifies and emits a CloudEvent the first time the controller finds the node of a PV missing
+func (r *PVCleanupController) reportOrphanFound(pv corev1.PersistentVolume, outcome reconcileOutcome) {
+	if state, ok := r.tracker().get(pv.Name); ok && state.orphan {
+		return
+	}
+	r.notify(pv, notify.KindOrphanFound, "Node of the PV is missing")
+	r.emit(pv, cloudevents.TypeOrphanDetected, outcome, string(causeOrphaned), r.auditEvidence(pv, causeOrphaned))
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/notify"
+)
+
+func TestPVCleanupController_notifications(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+	_ = storagev1.AddToScheme(s)
+
+	var mu sync.Mutex
+	var events []notify.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var payload struct {
+			Events []notify.Event `json:"events"`
+		}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&payload))
+		mu.Lock()
+		events = append(events, payload.Events...)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	notifier := notify.New(notify.Options{
+		Webhooks:      []notify.Webhook{{URL: server.URL, Format: notify.FormatJSON}},
+		BatchInterval: time.Hour,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = notifier.Start(ctx)
+		close(done)
+	}()
+
+	pvs := []*corev1.PersistentVolume{
+		newLocalPV("pv-retained", "topolvm", "node-gone"),
+		newLocalPV("pv-dry-run", "topolvm", "node-gone"),
+	}
+	pvs[0].Annotations = map[string]string{RetainUntilAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339)}
+	newController := func(dryRun bool) *PVCleanupController {
+		return &PVCleanupController{
+			Client: crFake.NewClientBuilder().WithScheme(s).WithObjects(pvs[0], pvs[1]).
+				WithIndex(&storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV).
+				Build(),
+			DryRun:           dryRun,
+			NodeSelectorKeys: []string{testNodeSelectorKey},
+			Notifier:         notifier,
+		}
+	}
+	reconcileTwice := func(r *PVCleanupController, name string) {
+		for range 2 {
+			_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+			require.NoError(t, err)
+		}
+	}
+
+	// a PV held by a guard is only notified once as orphan-found
+	reconcileTwice(newController(false), "pv-retained")
+	// a PV deleted in dry-run mode is only notified once
+	reconcileTwice(newController(true), "pv-dry-run")
+
+	cancel()
+	<-done
+
+	var got []string
+	for _, event := range events {
+		got = append(got, string(event.Kind)+"/"+event.PV)
+		assert.Equal(t, "node-gone", event.Node)
+	}
+	assert.Equal(t, []string{"orphan-found/pv-retained", "orphan-found/pv-dry-run", "dry-run/pv-dry-run"}, got)
+}
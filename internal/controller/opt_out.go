@@ -0,0 +1,70 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// annotationPrefix is the prefix of every annotation and label owned by the controller
+	annotationPrefix = "localpvcleaner.io/"
+
+	// SkipAnnotation opts a PV, or the PVC bound to it, out of the cleanup when set to "true"
+	SkipAnnotation = annotationPrefix + "skip"
+	// NamespaceOptInLabel opts a namespace in when the namespace opt-in mode is enabled
+	NamespaceOptInLabel = annotationPrefix + "enabled"
+)
+
+// isTrue reports whether the given key is set to a true value in the given map
+func isTrue(values map[string]string, key string) bool {
+	enabled, err := strconv.ParseBool(values[key])
+	return err == nil && enabled
+}
+
+// optOut returns the reason the PV, its PVC or its namespace opted out of the cleanup, if any
+func (p Policy) optOut(pv corev1.PersistentVolume, facts Facts) Reason {
+	if isTrue(pv.Annotations, SkipAnnotation) {
+		return ReasonPVAnnotation
+	}
+
+	if pv.Spec.ClaimRef == nil {
+		if p.NamespaceOptIn {
+			return ReasonNoClaim
+		}
+		return ""
+	}
+	if facts.Claim != nil && isTrue(facts.Claim.Annotations, SkipAnnotation) {
+		return ReasonPVCAnnotation
+	}
+	if p.NamespaceOptIn && (facts.Namespace == nil || !isTrue(facts.Namespace.Labels, NamespaceOptInLabel)) {
+		return ReasonNamespaceNotOptedIn
+	}
+
+	return ""
+}
+
+// recordSkip logs and counts a PV that the controller decided not to manage
+func recordSkip(ctx context.Context, pv corev1.PersistentVolume, reason Reason) {
+	log.FromContext(ctx).V(1).Info("Skipping PV", "pv", pv.Name, "reason", reason,
+		"storageClass", pv.Spec.StorageClassName)
+	skippedPVsTotal.WithLabelValues(pv.Spec.StorageClassName, string(reason)).Inc()
+}
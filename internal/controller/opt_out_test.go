@@ -0,0 +1,117 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPolicy_optOut(t *testing.T) {
+	claimRef := &corev1.ObjectReference{Namespace: "team-a", Name: "data"}
+
+	var tests = []struct {
+		name           string
+		facts          Facts
+		pv             corev1.PersistentVolume
+		namespaceOptIn bool
+		expectedReason Reason
+	}{
+		{
+			name: "Managed PV without annotations",
+			pv: corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+				Spec:       corev1.PersistentVolumeSpec{ClaimRef: claimRef},
+			},
+			expectedReason: "",
+		},
+		{
+			name: "Skip annotation on PV",
+			pv: corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Annotations: map[string]string{SkipAnnotation: "true"}},
+			},
+			expectedReason: ReasonPVAnnotation,
+		},
+		{
+			name: "Skip annotation on PV set to false",
+			pv: corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Annotations: map[string]string{SkipAnnotation: "false"}},
+			},
+			expectedReason: "",
+		},
+		{
+			name: "Skip annotation on PVC",
+			facts: Facts{Claim: &metav1.ObjectMeta{
+				Namespace: "team-a", Name: "data", Annotations: map[string]string{SkipAnnotation: "true"},
+			}},
+			pv: corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+				Spec:       corev1.PersistentVolumeSpec{ClaimRef: claimRef},
+			},
+			expectedReason: ReasonPVCAnnotation,
+		},
+		{
+			name: "Namespace opt-in without claim",
+			pv: corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+			},
+			namespaceOptIn: true,
+			expectedReason: ReasonNoClaim,
+		},
+		{
+			name:  "Namespace opt-in with unlabelled namespace",
+			facts: Facts{Namespace: &metav1.ObjectMeta{Name: "team-a"}},
+			pv: corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+				Spec:       corev1.PersistentVolumeSpec{ClaimRef: claimRef},
+			},
+			namespaceOptIn: true,
+			expectedReason: ReasonNamespaceNotOptedIn,
+		},
+		{
+			name: "Namespace opt-in with missing namespace",
+			pv: corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+				Spec:       corev1.PersistentVolumeSpec{ClaimRef: claimRef},
+			},
+			namespaceOptIn: true,
+			expectedReason: ReasonNamespaceNotOptedIn,
+		},
+		{
+			name: "Namespace opt-in with labelled namespace",
+			facts: Facts{Namespace: &metav1.ObjectMeta{
+				Name: "team-a", Labels: map[string]string{NamespaceOptInLabel: "true"},
+			}},
+			pv: corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+				Spec:       corev1.PersistentVolumeSpec{ClaimRef: claimRef},
+			},
+			namespaceOptIn: true,
+			expectedReason: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Policy{NamespaceOptIn: tt.namespaceOptIn}
+			assert.Equal(t, tt.expectedReason, p.optOut(tt.pv, tt.facts))
+		})
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// PauseKey is the key of the pause ConfigMap that pauses every deletion while it is true
+	PauseKey = "paused"
+	// PauseReasonKey is the optional key of the pause ConfigMap explaining why the deletions are paused
+	PauseReasonKey = "reason"
+)
+
+// ParsePauseConfigMap parses the namespace/name of the pause ConfigMap, the empty key is returned for an empty value
+func ParsePauseConfigMap(value string) (client.ObjectKey, error) {
+	if value == "" {
+		return client.ObjectKey{}, nil
+	}
+	namespace, name, ok := strings.Cut(value, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return client.ObjectKey{}, fmt.Errorf("pause ConfigMap %q is not of the form namespace/name", value)
+	}
+	return client.ObjectKey{Namespace: namespace, Name: name}, nil
+}
+
+// pausedMessage returns why the given pause ConfigMap pauses the deletions, empty when it does not
+func pausedMessage(cm *corev1.ConfigMap) string {
+	if cm == nil {
+		return ""
+	}
+	value, ok := cm.Data[PauseKey]
+	if !ok {
+		return ""
+	}
+
+	source := fmt.Sprintf("ConfigMap %s/%s", cm.Namespace, cm.Name)
+	paused, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		// whoever set an unreadable value most likely meant to stop the deletions
+		return fmt.Sprintf("Deletions are paused by %s, its %s value %q is not a boolean", source, PauseKey, value)
+	}
+	if !paused {
+		return ""
+	}
+	message := "Deletions are paused by " + source
+	if reason := strings.TrimSpace(cm.Data[PauseReasonKey]); reason != "" {
+		message += ": " + reason
+	}
+	return message
+}
+
+// PausedMessage reads the pause ConfigMap and returns why the deletions are paused, empty when they are not
+func (r *PVCleanupController) PausedMessage(ctx context.Context) (string, error) {
+	if r.PauseConfigMap.Name == "" {
+		return "", nil
+	}
+
+	var cm corev1.ConfigMap
+	if err := r.Client.Get(ctx, r.PauseConfigMap, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return pausedMessage(&cm), nil
+}
+
+// pauseHold trips while the pause ConfigMap pauses the deletions. The ConfigMap is read from the cache on every
+// check, so that a pause stops the deletions that did not start yet.
+func (r *PVCleanupController) pauseHold(ctx context.Context) (*guardTrip, error) {
+	message, err := r.PausedMessage(ctx)
+	if err != nil || message == "" {
+		return nil, err
+	}
+	return &guardTrip{Guard: guardPaused, Message: message, RequeueAfter: r.RequeueDuration}, nil
+}
+
+// pauseSwitch follows the pause ConfigMap to report the transitions of the paused state
+type pauseSwitch struct {
+	mu      sync.Mutex
+	message string
+	// resumed is signalled when the deletions resume
+	resumed chan struct{}
+}
+
+// pauseState returns the pause switch of the controller
+func (r *PVCleanupController) pauseState() *pauseSwitch {
+	r.pauseOnce.Do(func() {
+		r.pause = &pauseSwitch{resumed: make(chan struct{}, 1)}
+	})
+	return r.pause
+}
+
+// observePause records a new version of the pause ConfigMap, nil once it got deleted, and reports the transitions
+// of the paused state in the logs, the metrics and the events of the ConfigMap
+func (r *PVCleanupController) observePause(ctx context.Context, cm *corev1.ConfigMap) {
+	message := pausedMessage(cm)
+	s := r.pauseState()
+	s.mu.Lock()
+	previous := s.message
+	s.message = message
+	s.mu.Unlock()
+	if message == previous {
+		return
+	}
+
+	logger := log.FromContext(ctx)
+	if cm == nil {
+		cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.PauseConfigMap.Namespace,
+			Name:      r.PauseConfigMap.Name,
+		}}
+	}
+	if message != "" {
+		logger.Info("Deletions paused", "configMap", r.PauseConfigMap, "message", message)
+		deletionsPaused.Set(1)
+		if r.Recorder != nil {
+			r.Recorder.Event(cm, corev1.EventTypeWarning, "DeletionsPaused", message)
+		}
+		return
+	}
+
+	logger.Info("Deletions resumed", "configMap", r.PauseConfigMap)
+	deletionsPaused.Set(0)
+	if r.Recorder != nil {
+		r.Recorder.Event(cm, corev1.EventTypeNormal, "DeletionsResumed",
+			fmt.Sprintf("Deletions are resumed by ConfigMap %s", r.PauseConfigMap))
+	}
+	select {
+	case s.resumed <- struct{}{}:
+	default:
+	}
+}
+
+// pauseEventHandler feeds the changes of the pause ConfigMap to observePause
+func (r *PVCleanupController) pauseEventHandler(ctx context.Context) toolscache.ResourceEventHandler {
+	observe := func(obj any) {
+		if cm, ok := obj.(*corev1.ConfigMap); ok && client.ObjectKeyFromObject(cm) == r.PauseConfigMap {
+			r.observePause(ctx, cm)
+		}
+	}
+	return toolscache.ResourceEventHandlerFuncs{
+		AddFunc: observe,
+		UpdateFunc: func(_, obj any) {
+			observe(obj)
+		},
+		DeleteFunc: func(any) {
+			r.observePause(ctx, nil)
+		},
+	}
+}
+
+// pauseResumer enqueues the PVs held by the pause once the deletions resume, oldest orphans first
+type pauseResumer struct {
+	controller *PVCleanupController
+	events     chan<- event.GenericEvent
+}
+
+// Start waits for the deletions to resume until the context is cancelled
+func (p *pauseResumer) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-p.controller.pauseState().resumed:
+		}
+
+		held := p.controller.tracker().oldestFirst(guardPaused)
+		logger.Info("Enqueuing the PVs held by the pause", "held", len(held))
+		for _, name := range held {
+			select {
+			case p.events <- event.GenericEvent{Object: &corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: name}}}:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+// NeedLeaderElection makes sure only the leader enqueues the held PVs
+func (p *pauseResumer) NeedLeaderElection() bool {
+	return true
+}
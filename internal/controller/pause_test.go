@@ -0,0 +1,205 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// newPauseConfigMap returns the pause ConfigMap with the given data
+func newPauseConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "local-pv-cleaner", Name: "local-pv-cleaner-pause"},
+		Data:       data,
+	}
+}
+
+func TestParsePauseConfigMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    client.ObjectKey
+		wantErr bool
+	}{
+		{name: "empty", value: ""},
+		{name: "namespaced", value: "ops/pause", want: client.ObjectKey{Namespace: "ops", Name: "pause"}},
+		{name: "no namespace", value: "pause", wantErr: true},
+		{name: "empty namespace", value: "/pause", wantErr: true},
+		{name: "empty name", value: "ops/", wantErr: true},
+		{name: "too many parts", value: "ops/pause/now", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePauseConfigMap(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPausedMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		cm   *corev1.ConfigMap
+		want string
+	}{
+		{name: "no ConfigMap", cm: nil},
+		{name: "no key", cm: newPauseConfigMap(map[string]string{"other": "true"})},
+		{name: "false", cm: newPauseConfigMap(map[string]string{PauseKey: "false"})},
+		{
+			name: "true",
+			cm:   newPauseConfigMap(map[string]string{PauseKey: " True "}),
+			want: "Deletions are paused by ConfigMap local-pv-cleaner/local-pv-cleaner-pause",
+		},
+		{
+			name: "with a reason",
+			cm:   newPauseConfigMap(map[string]string{PauseKey: "1", PauseReasonKey: "INC-42 storage outage"}),
+			want: "Deletions are paused by ConfigMap local-pv-cleaner/local-pv-cleaner-pause: INC-42 storage outage",
+		},
+		{
+			name: "not a boolean",
+			cm:   newPauseConfigMap(map[string]string{PauseKey: "yes"}),
+			want: `Deletions are paused by ConfigMap local-pv-cleaner/local-pv-cleaner-pause, its paused value "yes" ` +
+				`is not a boolean`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pausedMessage(tt.cm))
+		})
+	}
+}
+
+func TestPVCleanupController_Reconcile_paused(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+
+	ctx := context.Background()
+	pv := newLocalPV("pv-1", "topolvm", "node-gone")
+	va := newVolumeAttachment("va-1", pv.Name, "node-gone")
+	cm := newPauseConfigMap(map[string]string{PauseKey: "true"})
+	fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(pv, va, cm).
+		WithIndex(&storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &PVCleanupController{
+		Client:            fakeClient,
+		NodeSelectorKeys:  []string{testNodeSelectorKey},
+		StorageClassNames: []string{"topolvm"},
+		RequeueDuration:   15 * time.Minute,
+		PauseConfigMap:    client.ObjectKeyFromObject(cm),
+		Recorder:          recorder,
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pv.Name}}
+
+	// while paused neither the PV nor its VolumeAttachments are deleted
+	result, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, result.RequeueAfter)
+	assert.Contains(t, <-recorder.Events, "DeletionBlocked Deletions are paused by ConfigMap")
+	assert.Equal(t, []string{pv.Name}, r.tracker().oldestFirst(guardPaused))
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(pv), &corev1.PersistentVolume{}))
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(va), &storagev1.VolumeAttachment{}))
+
+	// once resumed the PV is deleted
+	cm.Data[PauseKey] = "false"
+	require.NoError(t, fakeClient.Update(ctx, cm))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, r.tracker().oldestFirst(guardPaused))
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(pv), &corev1.PersistentVolume{})
+	assert.True(t, apierrors.IsNotFound(err), "Expected the PV to be deleted")
+}
+
+func TestPVCleanupController_observePause(t *testing.T) {
+	cm := newPauseConfigMap(map[string]string{PauseKey: "true", PauseReasonKey: "incident"})
+	recorder := record.NewFakeRecorder(10)
+	r := &PVCleanupController{PauseConfigMap: client.ObjectKeyFromObject(cm), Recorder: recorder}
+	ctx := context.Background()
+
+	r.observePause(ctx, cm)
+	assert.Equal(t, 1.0, testutil.ToFloat64(deletionsPaused))
+	assert.Equal(t, "Warning DeletionsPaused "+
+		"Deletions are paused by ConfigMap local-pv-cleaner/local-pv-cleaner-pause: incident", <-recorder.Events)
+
+	// an unchanged state is not reported again
+	r.observePause(ctx, cm)
+	assert.Empty(t, recorder.Events)
+
+	// deleting the ConfigMap resumes the deletions
+	r.observePause(ctx, nil)
+	assert.Equal(t, 0.0, testutil.ToFloat64(deletionsPaused))
+	assert.Contains(t, <-recorder.Events, "Normal DeletionsResumed")
+	select {
+	case <-r.pauseState().resumed:
+	default:
+		t.Fatal("Expected the resume to be signalled")
+	}
+}
+
+func TestPauseResumer(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	r := &PVCleanupController{PauseConfigMap: client.ObjectKey{Namespace: "ops", Name: "pause"}}
+	for name, since := range map[string]time.Duration{"pv-young": time.Hour, "pv-old": 48 * time.Hour} {
+		state := newPVState(corev1.PersistentVolume{}, guardPaused, true, false)
+		state.since = now.Add(-since)
+		r.tracker().set(name, state, now)
+	}
+	r.tracker().set("pv-window", newPVState(corev1.PersistentVolume{}, guardMaintenanceWindow, true, false), now)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan event.GenericEvent)
+	go func() {
+		_ = (&pauseResumer{controller: r, events: events}).Start(ctx)
+	}()
+	r.observePause(ctx, newPauseConfigMap(map[string]string{PauseKey: "true"}))
+	r.observePause(ctx, newPauseConfigMap(map[string]string{PauseKey: "false"}))
+
+	var names []string
+	for range 2 {
+		select {
+		case e := <-events:
+			names = append(names, e.Object.GetName())
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected the held PVs to be enqueued once the deletions resume")
+		}
+	}
+	assert.Equal(t, []string{"pv-old", "pv-young"}, names)
+}
@@ -0,0 +1,337 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/celpolicy"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/window"
+)
+
+// Verdict is what the controller does with a PV
+type Verdict string
+
+const (
+	// VerdictSkip is given to PVs the controller does not manage
+	VerdictSkip Verdict = "skip"
+	// VerdictKeep is given to PVs whose node exists
+	VerdictKeep Verdict = "keep"
+	// VerdictGrace is given to Released PVs waiting out the released TTL
+	VerdictGrace Verdict = "grace"
+	// VerdictHold is given to PVs a guard keeps from being deleted
+	VerdictHold Verdict = "hold"
+	// VerdictDelete is given to PVs that get deleted
+	VerdictDelete Verdict = "delete"
+)
+
+// Reason is why a verdict was given, also used as the reason label of the skipped PVs metric
+type Reason string
+
+const (
+	// ReasonReclaimPolicy skips PVs whose reclaim policy is not managed
+	ReasonReclaimPolicy Reason = "reclaim-policy"
+	// ReasonPhase skips PVs whose phase is not managed
+	ReasonPhase Reason = "phase"
+	// ReasonStorageClass skips PVs whose storage class is not managed
+	ReasonStorageClass Reason = "storage-class"
+	// ReasonNoNodeAffinity skips PVs without a node affinity on the node selector keys
+	ReasonNoNodeAffinity Reason = "no-node-affinity"
+	// ReasonPVAnnotation skips PVs carrying the SkipAnnotation
+	ReasonPVAnnotation Reason = "pv-skip-annotation"
+	// ReasonPVCAnnotation skips PVs whose claim carries the SkipAnnotation
+	ReasonPVCAnnotation Reason = "pvc-skip-annotation"
+	// ReasonNoClaim skips PVs without a claim in the namespace opt-in mode
+	ReasonNoClaim Reason = "no-claim"
+	// ReasonNamespaceNotOptedIn skips PVs whose claim namespace did not opt in
+	ReasonNamespaceNotOptedIn Reason = "namespace-not-opted-in"
+	// ReasonNodeExists keeps PVs whose node exists
+	ReasonNodeExists Reason = "node-exists"
+	// ReasonReleasedTTL holds Released PVs during the released TTL, and deletes them after it
+	ReasonReleasedTTL Reason = "released-ttl"
+	// ReasonSelector skips PVs the selector expression does not match, or fails on
+	ReasonSelector Reason = "selector"
+	// ReasonOrphanRule keeps orphaned PVs the orphan rule expression does not match, or fails on
+	ReasonOrphanRule Reason = "orphan-rule"
+	// ReasonPaused holds every PV while the deletions are paused
+	ReasonPaused Reason = "paused"
+	// ReasonRetentionHold holds PVs retained by the RetainUntilAnnotation
+	ReasonRetentionHold Reason = "retention-hold"
+	// ReasonApproval holds PVs whose OrphanedVolume is not approved
+	ReasonApproval Reason = "approval"
+	// ReasonMaintenanceWindow holds PVs outside the maintenance windows
+	ReasonMaintenanceWindow Reason = "maintenance-window"
+	// ReasonPolicyWebhook holds PVs the policy decision webhook denied or deferred
+	ReasonPolicyWebhook Reason = "policy-webhook"
+	// ReasonOrphaned deletes PVs whose node no longer exists
+	ReasonOrphaned Reason = "orphaned"
+)
+
+// NodeStatus is the status of the node a PV is bound to
+type NodeStatus string
+
+const (
+	// NodeStatusFound is set when the node of the PV exists
+	NodeStatusFound NodeStatus = "found"
+	// NodeStatusMissing is set when the node of the PV no longer exists
+	NodeStatusMissing NodeStatus = "missing"
+)
+
+// Policy is the configuration the decision engine evaluates PVs against
+type Policy struct {
+	NodeSelectorKeys  []string
+	StorageClassNames []string
+	// ReclaimPolicies are the managed reclaim policies, only Retain is managed when empty
+	ReclaimPolicies []corev1.PersistentVolumeReclaimPolicy
+	// Phases are the managed PV phases, every phase is managed when empty
+	Phases []corev1.PersistentVolumePhase
+	// ReleasedTTL is how long a Retain PV may stay Released on a healthy node, Released PVs are kept when zero
+	ReleasedTTL time.Duration
+	// NamespaceOptIn restricts the cleanup to PVs whose claim namespace carries the NamespaceOptInLabel
+	NamespaceOptIn bool
+	// Selector restricts the managed PVs to the ones it matches, every PV is managed when nil
+	Selector *celpolicy.Expression
+	// OrphanRule must match an orphaned PV for it to be deleted, every orphan is deleted when nil
+	OrphanRule *celpolicy.Expression
+	// MaintenanceWindows restricts the deletions to the times a window is open, deletions are not restricted when empty
+	MaintenanceWindows window.Schedule
+}
+
+// Facts is what the cluster tells about a PV, gathered by the reconciler for the decision engine
+type Facts struct {
+	// Now is the time of the decision
+	Now time.Time
+	// NodeFound is set when the node resolved from the node affinity of the PV exists
+	NodeFound bool
+	// Node is the metadata of the node of the PV, nil when it is missing or was not looked up
+	Node *metav1.ObjectMeta
+	// LastNodeLabels are the labels last seen on the node of the PV once it is missing, nil when they are unknown
+	LastNodeLabels map[string]string
+	// Claim is the metadata of the claim of the PV, nil when the PV has no claim or the claim is gone
+	Claim *metav1.ObjectMeta
+	// Namespace is the metadata of the claim namespace, nil when the PV has no claim or the namespace is gone
+	Namespace *metav1.ObjectMeta
+	// TrackedSince is when the controller first saw the PV orphaned or released, zero when it is not tracked
+	TrackedSince time.Time
+}
+
+// Decision is the outcome of the decision engine for a PV
+type Decision struct {
+	PV           string `json:"pv"`
+	StorageClass string `json:"storageClass"`
+	// Node is the node resolved from the node affinity of the PV
+	Node string `json:"node,omitempty"`
+	// NodeStatus is empty when the PV got skipped before its node was looked up
+	NodeStatus NodeStatus `json:"nodeStatus,omitempty"`
+	Verdict    Verdict    `json:"verdict"`
+	Reason     Reason     `json:"reason"`
+	// Remaining is how long the PV still waits out its released TTL or hold
+	Remaining time.Duration `json:"-"`
+	Message   string        `json:"message,omitempty"`
+	// Evidence are the facts supporting the verdict, set once the PV got past the filters
+	Evidence map[string]string `json:"evidence,omitempty"`
+	// Warning marks decisions forced by an expression failing to evaluate, the Message holds the error
+	Warning bool `json:"warning,omitempty"`
+}
+
+// Orphaned reports whether the node of the PV is missing
+func (d Decision) Orphaned() bool {
+	return d.NodeStatus == NodeStatusMissing
+}
+
+// Evaluate decides what to do with a PV from the policy and the facts, without any side effect
+func (p Policy) Evaluate(pv corev1.PersistentVolume, facts Facts) Decision {
+	d := Decision{PV: pv.Name, StorageClass: pv.Spec.StorageClassName}
+
+	// skip if the PV is filtered out, or it, its PVC or its namespace opted out of the cleanup
+	if reason := p.filter(pv); reason != "" {
+		d.Verdict, d.Reason = VerdictSkip, reason
+		return d
+	}
+	if reason := p.optOut(pv, facts); reason != "" {
+		d.Verdict, d.Reason = VerdictSkip, reason
+		return d
+	}
+	d.Node = p.node(pv)
+	if d.Node == "" {
+		d.Verdict, d.Reason = VerdictSkip, ReasonNoNodeAffinity
+		return d
+	}
+	if !d.matches(p.Selector, pv, facts) {
+		d.Verdict, d.Reason = VerdictSkip, ReasonSelector
+		return d
+	}
+
+	if !facts.NodeFound {
+		// node doesn't exist, the PV is deleted unless the orphan rule keeps it
+		d.NodeStatus = NodeStatusMissing
+		d.Evidence = p.evidence(pv, facts, causeOrphaned)
+		if !d.matches(p.OrphanRule, pv, facts) {
+			d.Verdict, d.Reason = VerdictKeep, ReasonOrphanRule
+			return d
+		}
+		d.Verdict, d.Reason = VerdictDelete, ReasonOrphaned
+		return p.guard(d, pv, facts)
+	}
+
+	// node exists, the PV is only deleted if it has been Released for too long
+	d.NodeStatus = NodeStatusFound
+	if !p.collectsReleased(pv) {
+		d.Verdict, d.Reason = VerdictKeep, ReasonNodeExists
+		d.Evidence = p.evidence(pv, facts, "")
+		return d
+	}
+	d.Evidence = p.evidence(pv, facts, causeReleasedTTL)
+	if remaining := p.releasedTTLRemaining(pv, facts); remaining > 0 {
+		d.Verdict, d.Reason, d.Remaining = VerdictGrace, ReasonReleasedTTL, remaining
+		d.Message = fmt.Sprintf("Released TTL ends in %s", remaining.Round(time.Second))
+		return d
+	}
+	d.Verdict, d.Reason = VerdictDelete, ReasonReleasedTTL
+	return p.guard(d, pv, facts)
+}
+
+// matches evaluates an optional expression over the PV and the facts, a failure is recorded as a warning
+// and never matches
+func (d *Decision) matches(expr *celpolicy.Expression, pv corev1.PersistentVolume, facts Facts) bool {
+	if expr == nil {
+		return true
+	}
+
+	vars := celpolicy.Variables{
+		PV: pv, Claim: facts.Claim, Namespace: facts.Namespace, Node: facts.Node, Now: facts.Now,
+	}
+	if !facts.NodeFound {
+		vars.OrphanedAt = facts.orphanedSince()
+		vars.NodeLabels = facts.LastNodeLabels
+	}
+	if pv.Status.Phase == corev1.VolumeReleased {
+		vars.ReleasedAt, _ = releasedAt(pv)
+	}
+	matched, err := expr.Eval(vars)
+	if err != nil {
+		d.Warning = true
+		d.Message = err.Error()
+	}
+	return err == nil && matched
+}
+
+// guard turns a delete verdict into a hold when a guard trips
+func (p Policy) guard(d Decision, pv corev1.PersistentVolume, facts Facts) Decision {
+	trip := retentionHold(pv, facts)
+	if trip == nil {
+		trip = p.maintenanceHold(facts.Now)
+	}
+	if trip != nil {
+		d.Verdict, d.Reason, d.Remaining = VerdictHold, Reason(trip.Guard), trip.RequeueAfter
+		d.Message = trip.Message
+	}
+	return d
+}
+
+// filter returns the reason the PV is filtered out by its reclaim policy, phase or storage class, if any
+func (p Policy) filter(pv corev1.PersistentVolume) Reason {
+	if !p.managesReclaimPolicy(pv) {
+		return ReasonReclaimPolicy
+	}
+	if !p.managesPhase(pv) {
+		return ReasonPhase
+	}
+	if len(p.StorageClassNames) > 0 && !slices.Contains(p.StorageClassNames, pv.Spec.StorageClassName) {
+		return ReasonStorageClass
+	}
+	return ""
+}
+
+// node returns the node resolved from the node affinity of the PV
+func (p Policy) node(pv corev1.PersistentVolume) string {
+	return getNodeNameFromAffinity(pv.Spec.NodeAffinity, p.NodeSelectorKeys)
+}
+
+// collectsReleased reports whether the PV is a Released PV the released TTL applies to
+func (p Policy) collectsReleased(pv corev1.PersistentVolume) bool {
+	return p.ReleasedTTL > 0 && pv.Spec.PersistentVolumeReclaimPolicy == corev1.PersistentVolumeReclaimRetain &&
+		pv.Status.Phase == corev1.VolumeReleased
+}
+
+// releasedTTLRemaining returns how long a Released PV may still stay Released
+func (p Policy) releasedTTLRemaining(pv corev1.PersistentVolume, facts Facts) time.Duration {
+	since, _ := releasedAt(pv)
+	if since.IsZero() {
+		since = facts.Now
+	}
+	return since.Add(p.ReleasedTTL).Sub(facts.Now)
+}
+
+// orphanedSince returns when the PV was first found orphaned from the state tracker, falling back to the time
+// of the decision
+func (f Facts) orphanedSince() time.Time {
+	if !f.TrackedSince.IsZero() {
+		return f.TrackedSince
+	}
+	return f.Now
+}
+
+// evidence returns the facts supporting a decision about the PV, with the details of the given deletion cause
+func (p Policy) evidence(pv corev1.PersistentVolume, facts Facts, cause deletionCause) map[string]string {
+	evidence := map[string]string{
+		"phase":         string(pv.Status.Phase),
+		"reclaimPolicy": string(pv.Spec.PersistentVolumeReclaimPolicy),
+		"nodeSelector":  strings.Join(p.NodeSelectorKeys, ","),
+	}
+	switch cause {
+	case causeOrphaned:
+		evidence["nodeFound"] = "false"
+		evidence["orphanedSince"] = facts.orphanedSince().UTC().Format(time.RFC3339)
+	case causeReleasedTTL:
+		evidence["nodeFound"] = "true"
+		if since, _ := releasedAt(pv); !since.IsZero() {
+			evidence["releasedAt"] = since.UTC().Format(time.RFC3339)
+		}
+		evidence["releasedTTL"] = p.ReleasedTTL.String()
+	default:
+		evidence["nodeFound"] = "true"
+	}
+	for _, key := range []string{RetainUntilAnnotation, SkipAnnotation} {
+		if value, ok := pv.Annotations[key]; ok {
+			evidence[key] = value
+		}
+	}
+
+	return evidence
+}
+
+// policy returns the policy the controller is configured with
+func (r *PVCleanupController) policy() Policy {
+	return Policy{
+		NodeSelectorKeys:   r.NodeSelectorKeys,
+		StorageClassNames:  r.StorageClassNames,
+		ReclaimPolicies:    r.ReclaimPolicies,
+		Phases:             r.Phases,
+		ReleasedTTL:        r.ReleasedTTL,
+		NamespaceOptIn:     r.NamespaceOptIn,
+		Selector:           r.Selector,
+		OrphanRule:         r.OrphanRule,
+		MaintenanceWindows: r.MaintenanceWindows,
+	}
+}
@@ -0,0 +1,325 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/celpolicy"
+)
+
+func TestPolicy_Evaluate(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	policy := Policy{
+		NodeSelectorKeys:  []string{testNodeSelectorKey},
+		StorageClassNames: []string{"topolvm"},
+		ReleasedTTL:       24 * time.Hour,
+	}
+	annotated := func(pv *corev1.PersistentVolume, key, value string) corev1.PersistentVolume {
+		pv.Annotations = map[string]string{key: value}
+		return *pv
+	}
+	released := func(pv *corev1.PersistentVolume, at string) corev1.PersistentVolume {
+		pv.Status.Phase = corev1.VolumeReleased
+		return annotated(pv, ReleasedAtAnnotation, at)
+	}
+	claimed := func(pv *corev1.PersistentVolume) corev1.PersistentVolume {
+		pv.Spec.ClaimRef = &corev1.ObjectReference{Namespace: "team-a", Name: "data"}
+		return *pv
+	}
+	deletePolicy := newLocalPV("pv-1", "topolvm", "node-01")
+	deletePolicy.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimDelete
+	noAffinity := newLocalPV("pv-1", "topolvm", "node-01")
+	noAffinity.Spec.NodeAffinity = nil
+
+	var tests = []struct {
+		name         string
+		pv           corev1.PersistentVolume
+		facts        Facts
+		wantVerdict  Verdict
+		wantReason   Reason
+		wantMessage  string
+		wantEvidence map[string]string
+	}{
+		{
+			name:        "Reclaim policy not managed",
+			pv:          *deletePolicy,
+			wantVerdict: VerdictSkip,
+			wantReason:  ReasonReclaimPolicy,
+		},
+		{
+			name:        "No node affinity",
+			pv:          *noAffinity,
+			wantVerdict: VerdictSkip,
+			wantReason:  ReasonNoNodeAffinity,
+		},
+		{
+			name: "Claim opted out",
+			pv:   claimed(newLocalPV("pv-1", "topolvm", "node-gone")),
+			facts: Facts{Claim: &metav1.ObjectMeta{
+				Name: "data", Namespace: "team-a", Annotations: map[string]string{SkipAnnotation: "true"},
+			}},
+			wantVerdict: VerdictSkip,
+			wantReason:  ReasonPVCAnnotation,
+		},
+		{
+			name:        "Node exists",
+			pv:          *newLocalPV("pv-1", "topolvm", "node-01"),
+			facts:       Facts{NodeFound: true},
+			wantVerdict: VerdictKeep,
+			wantReason:  ReasonNodeExists,
+			wantEvidence: map[string]string{
+				"phase": "Bound", "reclaimPolicy": "Retain", "nodeSelector": testNodeSelectorKey, "nodeFound": "true",
+			},
+		},
+		{
+			name:        "Orphan",
+			pv:          *newLocalPV("pv-1", "topolvm", "node-gone"),
+			wantVerdict: VerdictDelete,
+			wantReason:  ReasonOrphaned,
+			wantEvidence: map[string]string{
+				"phase": "Bound", "reclaimPolicy": "Retain", "nodeSelector": testNodeSelectorKey, "nodeFound": "false",
+				"orphanedSince": "2025-03-01T12:00:00Z",
+			},
+		},
+		{
+			name:        "Orphan tracked since earlier",
+			pv:          *newLocalPV("pv-1", "topolvm", "node-gone"),
+			facts:       Facts{TrackedSince: now.Add(-30 * time.Minute)},
+			wantVerdict: VerdictDelete,
+			wantReason:  ReasonOrphaned,
+			wantEvidence: map[string]string{
+				"phase": "Bound", "reclaimPolicy": "Retain", "nodeSelector": testNodeSelectorKey, "nodeFound": "false",
+				"orphanedSince": "2025-03-01T11:30:00Z",
+			},
+		},
+		{
+			name: "Orphan retained by its namespace",
+			pv:   claimed(newLocalPV("pv-1", "topolvm", "node-gone")),
+			facts: Facts{
+				TrackedSince: now.Add(-2 * time.Hour),
+				Namespace: &metav1.ObjectMeta{
+					Name: "team-a", Annotations: map[string]string{RetainUntilAnnotation: "2025-03-01T18:00:00Z"},
+				},
+			},
+			wantVerdict: VerdictHold,
+			wantReason:  ReasonRetentionHold,
+			wantMessage: "PV is retained until 2025-03-01T18:00:00Z by Namespace team-a",
+			wantEvidence: map[string]string{
+				"phase": "Bound", "reclaimPolicy": "Retain", "nodeSelector": testNodeSelectorKey, "nodeFound": "false",
+				"orphanedSince": "2025-03-01T10:00:00Z",
+			},
+		},
+		{
+			name:        "Released past TTL",
+			pv:          released(newLocalPV("pv-1", "topolvm", "node-01"), "2025-02-27T11:00:00Z"),
+			facts:       Facts{NodeFound: true},
+			wantVerdict: VerdictDelete,
+			wantReason:  ReasonReleasedTTL,
+			wantEvidence: map[string]string{
+				"phase": "Released", "reclaimPolicy": "Retain", "nodeSelector": testNodeSelectorKey, "nodeFound": "true",
+				"releasedAt": "2025-02-27T11:00:00Z", "releasedTTL": "24h0m0s",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.facts.Now = now
+			got := policy.Evaluate(tt.pv, tt.facts)
+			assert.Equal(t, tt.wantVerdict, got.Verdict)
+			assert.Equal(t, tt.wantReason, got.Reason)
+			assert.Equal(t, tt.wantMessage, got.Message)
+			assert.Equal(t, tt.wantEvidence, got.Evidence)
+		})
+	}
+}
+
+func TestPolicy_Evaluate_expressions(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	compile := func(name, source string) *celpolicy.Expression {
+		expr, err := celpolicy.Compile(name, source, 0)
+		require.NoError(t, err)
+		return expr
+	}
+	bigPV := newLocalPV("pv-1", "topolvm", "node-gone")
+	bigPV.Spec.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("200Gi")}
+	bigPV.Spec.ClaimRef = &corev1.ObjectReference{Namespace: "team-a", Name: "data"}
+	batch := &metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"tier": "batch"}}
+
+	var tests = []struct {
+		name        string
+		selector    string
+		orphanRule  string
+		pv          corev1.PersistentVolume
+		facts       Facts
+		wantVerdict Verdict
+		wantReason  Reason
+		wantWarning bool
+	}{
+		{
+			name:        "Selector matches",
+			selector:    `quantity(pv.spec.capacity.storage).isGreaterThan(quantity("100Gi")) && ns.metadata.labels["tier"] == "batch"`,
+			pv:          *bigPV,
+			facts:       Facts{Namespace: batch},
+			wantVerdict: VerdictDelete,
+			wantReason:  ReasonOrphaned,
+		},
+		{
+			name:        "Selector does not match",
+			selector:    `ns != null && ns.metadata.labels["tier"] == "batch"`,
+			pv:          *bigPV,
+			wantVerdict: VerdictSkip,
+			wantReason:  ReasonSelector,
+		},
+		{
+			name:        "Selector fails",
+			selector:    `ns.metadata.labels["tier"] == "batch"`,
+			pv:          *bigPV,
+			wantVerdict: VerdictSkip,
+			wantReason:  ReasonSelector,
+			wantWarning: true,
+		},
+		{
+			name:        "Selector sees the node",
+			selector:    `node == null || node.metadata.labels["lifecycle"] != "spot"`,
+			pv:          *newLocalPV("pv-1", "topolvm", "node-01"),
+			facts:       Facts{NodeFound: true, Node: &metav1.ObjectMeta{Name: "node-01", Labels: map[string]string{"lifecycle": "spot"}}},
+			wantVerdict: VerdictSkip,
+			wantReason:  ReasonSelector,
+		},
+		{
+			name:        "Orphan rule keeps a recent orphan",
+			orphanRule:  `now - recorded.orphanedAt > duration("6h")`,
+			pv:          *bigPV,
+			facts:       Facts{TrackedSince: now.Add(-time.Hour)},
+			wantVerdict: VerdictKeep,
+			wantReason:  ReasonOrphanRule,
+		},
+		{
+			name:        "Orphan rule matches an old orphan",
+			orphanRule:  `now - recorded.orphanedAt > duration("6h")`,
+			pv:          *bigPV,
+			facts:       Facts{TrackedSince: now.Add(-7 * time.Hour)},
+			wantVerdict: VerdictDelete,
+			wantReason:  ReasonOrphaned,
+		},
+		{
+			name:        "Orphan rule sees the labels last seen on the node",
+			orphanRule:  `has(recorded.nodeLabels) && recorded.nodeLabels["lifecycle"] == "spot"`,
+			pv:          *bigPV,
+			facts:       Facts{LastNodeLabels: map[string]string{"lifecycle": "spot"}},
+			wantVerdict: VerdictDelete,
+			wantReason:  ReasonOrphaned,
+		},
+		{
+			name:        "Orphan rule keeps orphans of unknown nodes",
+			orphanRule:  `has(recorded.nodeLabels) && recorded.nodeLabels["lifecycle"] == "spot"`,
+			pv:          *bigPV,
+			wantVerdict: VerdictKeep,
+			wantReason:  ReasonOrphanRule,
+		},
+		{
+			name:        "Orphan rule does not apply to healthy nodes",
+			orphanRule:  `false`,
+			pv:          *newLocalPV("pv-1", "topolvm", "node-01"),
+			facts:       Facts{NodeFound: true},
+			wantVerdict: VerdictKeep,
+			wantReason:  ReasonNodeExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := Policy{NodeSelectorKeys: []string{testNodeSelectorKey}}
+			if tt.selector != "" {
+				policy.Selector = compile("selector", tt.selector)
+			}
+			if tt.orphanRule != "" {
+				policy.OrphanRule = compile("orphanRule", tt.orphanRule)
+			}
+			tt.facts.Now = now
+
+			got := policy.Evaluate(tt.pv, tt.facts)
+			assert.Equal(t, tt.wantVerdict, got.Verdict)
+			assert.Equal(t, tt.wantReason, got.Reason)
+			assert.Equal(t, tt.wantWarning, got.Warning)
+			if tt.wantWarning {
+				assert.Contains(t, got.Message, "selector: ")
+			}
+		})
+	}
+}
+
+func TestPVCleanupController_Reconcile_expressions(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+
+	ctx := context.Background()
+	kept := newLocalPV("pv-kept", "topolvm", "node-gone")
+	failing := newLocalPV("pv-failing", "topolvm", "node-gone")
+	failing.Labels = map[string]string{"tier": "batch"}
+	fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(kept, failing).Build()
+	recorder := record.NewFakeRecorder(2)
+
+	selector, err := celpolicy.Compile("selector", `!("tier" in pv.metadata.labels) || pvc.metadata.name != ""`, 0)
+	require.NoError(t, err)
+	orphanRule, err := celpolicy.Compile("orphanRule", `pv.metadata.name != "pv-kept"`, 0)
+	require.NoError(t, err)
+	r := &PVCleanupController{
+		Client:            fakeClient,
+		NodeSelectorKeys:  []string{testNodeSelectorKey},
+		StorageClassNames: []string{"topolvm"},
+		RequeueDuration:   15 * time.Minute,
+		Selector:          selector,
+		OrphanRule:        orphanRule,
+		Recorder:          recorder,
+	}
+
+	// the orphan rule keeps the PV, which is tracked as an orphan that is not deleted
+	result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: kept.Name}})
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, result.RequeueAfter)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(kept), &corev1.PersistentVolume{}))
+	assert.NoError(t, testutil.CollectAndCompare(newStateCollector(r.tracker()), strings.NewReader(`
+# HELP local_pv_cleaner_orphaned_pvs Number of Orphaned PVs that are not deleted yet
+# TYPE local_pv_cleaner_orphaned_pvs gauge
+local_pv_cleaner_orphaned_pvs{reason="orphan-rule",storage_class="topolvm"} 1
+`), "local_pv_cleaner_orphaned_pvs"))
+
+	// the selector fails on a PV without a claim, the PV is skipped with a warning event
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: failing.Name}})
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(failing), &corev1.PersistentVolume{}))
+	assert.Contains(t, <-recorder.Events, "PolicyFailed")
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/policyhook"
+)
+
+// reviewDeletion asks the policy decision webhook whether the PV may be deleted and returns a guard trip
+// when the deletion was denied or deferred
+func (r *PVCleanupController) reviewDeletion(ctx context.Context, pv corev1.PersistentVolume, cause deletionCause,
+	action orphanAction) (*guardTrip, error) {
+	if r.PolicyHook == nil {
+		return nil, nil
+	}
+	logger := log.FromContext(ctx)
+
+	req := policyhook.Request{
+		Action: string(action),
+		Cause:  string(cause),
+		PV:     &pv,
+		Node: policyhook.Node{
+			Name:  getNodeNameFromAffinity(pv.Spec.NodeAffinity, r.NodeSelectorKeys),
+			Found: cause != causeOrphaned,
+		},
+		Evidence: r.auditEvidence(pv, cause),
+	}
+	if claim := pv.Spec.ClaimRef; claim != nil {
+		// the cache only holds the metadata of the claims
+		var pvc corev1.PersistentVolumeClaim
+		err := r.apiReader().Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: claim.Name}, &pvc)
+		if client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+		if err == nil && (claim.UID == "" || claim.UID == pvc.UID) {
+			req.PVC = &pvc
+		}
+	}
+
+	verdict := r.PolicyHook.Review(ctx, req)
+	if verdict.Err != nil {
+		logger.Error(verdict.Err, "Policy webhook review failed", "pv", pv.Name, "decision", verdict.Decision)
+	}
+	switch verdict.Decision {
+	case policyhook.Deny:
+		return &guardTrip{
+			Guard:        guardPolicyWebhook,
+			Message:      "Deletion denied by the policy webhook: " + verdict.Reason,
+			RequeueAfter: r.RequeueDuration,
+		}, nil
+	case policyhook.Defer:
+		return &guardTrip{
+			Guard:        guardPolicyWebhook,
+			Message:      "Deletion deferred by the policy webhook: " + verdict.Reason,
+			Warning:      verdict.Err != nil,
+			RequeueAfter: verdict.RetryAfter,
+		}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/policyhook"
+)
+
+func TestPVCleanupController_deleteOrphanedPV_policyWebhook(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+
+	var tests = []struct {
+		name             string
+		response         *policyhook.Response
+		wantDeleted      bool
+		wantRequeueAfter time.Duration
+		wantEvent        string
+	}{
+		{
+			name:        "Allowed",
+			response:    &policyhook.Response{Decision: policyhook.Allow},
+			wantDeleted: true,
+		},
+		{
+			name:             "Denied",
+			response:         &policyhook.Response{Decision: policyhook.Deny, Reason: "change freeze"},
+			wantRequeueAfter: 15 * time.Minute,
+			wantEvent:        "Normal DeletionBlocked Deletion denied by the policy webhook: change freeze",
+		},
+		{
+			name:             "Deferred",
+			response:         &policyhook.Response{Decision: policyhook.Defer, Reason: "asking", RetryAfterSeconds: 60},
+			wantRequeueAfter: time.Minute,
+			wantEvent:        "Normal DeletionBlocked Deletion deferred by the policy webhook: asking",
+		},
+		{
+			name:             "Webhook failure fails closed",
+			wantRequeueAfter: 15 * time.Minute,
+			wantEvent:        "Warning DeletionBlocked Deletion deferred by the policy webhook: Policy webhook failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			pv := &corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+				Spec: corev1.PersistentVolumeSpec{
+					ClaimRef: &corev1.ObjectReference{Namespace: "team-a", Name: "data"},
+					NodeAffinity: &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{
+							{Key: testNodeSelectorKey, Operator: corev1.NodeSelectorOpIn, Values: []string{"node-gone"}},
+						}}},
+					}},
+				},
+			}
+			pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "data"}}
+			fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(pv, pvc).
+				WithIndex(&storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV).Build()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req policyhook.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, "delete", req.Action)
+				assert.Equal(t, "orphaned", req.Cause)
+				assert.Equal(t, policyhook.Node{Name: "node-gone"}, req.Node)
+				require.NotNil(t, req.PVC)
+				assert.Equal(t, "data", req.PVC.Name)
+				assert.Equal(t, "false", req.Evidence["nodeFound"])
+				if tt.response == nil {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(tt.response)
+			}))
+			defer srv.Close()
+			hook, err := policyhook.New(policyhook.Options{URL: srv.URL})
+			require.NoError(t, err)
+
+			recorder := record.NewFakeRecorder(1)
+			r := &PVCleanupController{
+				Client:           fakeClient,
+				NodeSelectorKeys: []string{testNodeSelectorKey},
+				RequeueDuration:  15 * time.Minute,
+				PolicyHook:       hook,
+				Recorder:         recorder,
+				Clock:            clocktesting.NewFakePassiveClock(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)),
+			}
+
+			result, _, err := r.deleteOrphanedPV(ctx, *pv)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRequeueAfter, result.RequeueAfter)
+			err = fakeClient.Get(ctx, client.ObjectKey{Name: pv.Name}, &corev1.PersistentVolume{})
+			assert.Equal(t, tt.wantDeleted, err != nil)
+			if tt.wantEvent != "" {
+				assert.Contains(t, <-recorder.Events, tt.wantEvent)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// pvPredicate enqueues created and deleted PVs and the PV updates that can change the cleanup decision
+func pvPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return true },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPV, ok := e.ObjectOld.(*corev1.PersistentVolume)
+			if !ok {
+				return false
+			}
+			newPV, ok := e.ObjectNew.(*corev1.PersistentVolume)
+			if !ok {
+				return false
+			}
+			return pvUpdateRelevant(oldPV, newPV)
+		},
+		// deletions are reconciled so that the PV is dropped from the lifecycle gauges
+		DeleteFunc:  func(e event.DeleteEvent) bool { return true },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+// pvUpdateRelevant reports whether an update changed anything the cleanup decision depends on.
+// Status noise, such as a new message or resourceVersion, is ignored.
+func pvUpdateRelevant(oldPV, newPV *corev1.PersistentVolume) bool {
+	switch {
+	case oldPV.Status.Phase != newPV.Status.Phase:
+		return true
+	case !claimRefEqual(oldPV.Spec.ClaimRef, newPV.Spec.ClaimRef):
+		return true
+	case !equality.Semantic.DeepEqual(oldPV.Spec.NodeAffinity, newPV.Spec.NodeAffinity):
+		return true
+	case oldPV.Spec.StorageClassName != newPV.Spec.StorageClassName:
+		return true
+	case oldPV.Spec.PersistentVolumeReclaimPolicy != newPV.Spec.PersistentVolumeReclaimPolicy:
+		return true
+	case oldPV.DeletionTimestamp.IsZero() != newPV.DeletionTimestamp.IsZero():
+		return true
+	}
+
+	return !equality.Semantic.DeepEqual(ownedAnnotations(oldPV.Annotations), ownedAnnotations(newPV.Annotations))
+}
+
+// claimRefEqual compares the claim identity of two claim references, ignoring their resourceVersion
+func claimRefEqual(a, b *corev1.ObjectReference) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Namespace == b.Namespace && a.Name == b.Name && a.UID == b.UID
+}
+
+// ownedAnnotations returns the annotations owned by the controller
+func ownedAnnotations(annotations map[string]string) map[string]string {
+	owned := map[string]string{}
+	for key, value := range annotations {
+		if strings.HasPrefix(key, annotationPrefix) {
+			owned[key] = value
+		}
+	}
+
+	return owned
+}
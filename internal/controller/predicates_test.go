@@ -0,0 +1,135 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestPVUpdatePredicate(t *testing.T) {
+	base := func() *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "pv-1",
+				ResourceVersion: "1",
+				Annotations:     map[string]string{"example.com/owner": "team-a"},
+			},
+			Spec: corev1.PersistentVolumeSpec{
+				StorageClassName:              "topolvm",
+				PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+				ClaimRef:                      &corev1.ObjectReference{Namespace: "team-a", Name: "data", UID: "uid-1", ResourceVersion: "10"},
+				NodeAffinity: &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "topology.topolvm.io/node", Values: []string{"node-01"}}},
+					}},
+				}},
+			},
+			Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+		}
+	}
+
+	var tests = []struct {
+		name    string
+		mutate  func(pv *corev1.PersistentVolume)
+		enqueue bool
+	}{
+		{
+			name:    "Unchanged",
+			mutate:  func(pv *corev1.PersistentVolume) {},
+			enqueue: false,
+		},
+		{
+			name: "Status noise",
+			mutate: func(pv *corev1.PersistentVolume) {
+				pv.ResourceVersion = "2"
+				pv.Status.Message = "still bound"
+				pv.Spec.ClaimRef.ResourceVersion = "11"
+			},
+			enqueue: false,
+		},
+		{
+			name:    "Foreign annotation",
+			mutate:  func(pv *corev1.PersistentVolume) { pv.Annotations["example.com/owner"] = "team-b" },
+			enqueue: false,
+		},
+		{
+			name:    "Phase change",
+			mutate:  func(pv *corev1.PersistentVolume) { pv.Status.Phase = corev1.VolumeReleased },
+			enqueue: true,
+		},
+		{
+			name:    "ClaimRef removed",
+			mutate:  func(pv *corev1.PersistentVolume) { pv.Spec.ClaimRef = nil },
+			enqueue: true,
+		},
+		{
+			name:    "ClaimRef rebound",
+			mutate:  func(pv *corev1.PersistentVolume) { pv.Spec.ClaimRef.UID = "uid-2" },
+			enqueue: true,
+		},
+		{
+			name: "Node affinity change",
+			mutate: func(pv *corev1.PersistentVolume) {
+				pv.Spec.NodeAffinity.Required.NodeSelectorTerms[0].MatchExpressions[0].Values = []string{"node-02"}
+			},
+			enqueue: true,
+		},
+		{
+			name:    "StorageClass change",
+			mutate:  func(pv *corev1.PersistentVolume) { pv.Spec.StorageClassName = "openebs" },
+			enqueue: true,
+		},
+		{
+			name: "Reclaim policy change",
+			mutate: func(pv *corev1.PersistentVolume) {
+				pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimDelete
+			},
+			enqueue: true,
+		},
+		{
+			name:    "Deletion started",
+			mutate:  func(pv *corev1.PersistentVolume) { pv.DeletionTimestamp = ptr.To(metav1.Now()) },
+			enqueue: true,
+		},
+		{
+			name:    "Owned annotation added",
+			mutate:  func(pv *corev1.PersistentVolume) { pv.Annotations[SkipAnnotation] = "true" },
+			enqueue: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldPV := base()
+			newPV := base()
+			tt.mutate(newPV)
+
+			got := pvPredicate().Update(event.UpdateEvent{ObjectOld: oldPV, ObjectNew: newPV})
+			assert.Equal(t, tt.enqueue, got)
+		})
+	}
+
+	assert.True(t, pvPredicate().Create(event.CreateEvent{Object: base()}))
+	assert.True(t, pvPredicate().Delete(event.DeleteEvent{Object: base()}))
+	assert.False(t, pvPredicate().Update(event.UpdateEvent{ObjectOld: &corev1.Node{}, ObjectNew: &corev1.Node{}}))
+}
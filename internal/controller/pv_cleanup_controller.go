@@ -18,18 +18,36 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
-	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
-	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kavinraja-g/local-pv-cleaner/api/v1alpha1"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/audit"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/backup"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/celpolicy"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/cloudevents"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/notify"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/policyhook"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/window"
 
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -42,73 +60,205 @@ type PVCleanupController struct {
 	NodeSelectorKeys  []string
 	StorageClassNames []string
 	RequeueDuration   time.Duration
-}
+	// ReclaimPolicies are the managed reclaim policies, only Retain is managed when empty
+	ReclaimPolicies []corev1.PersistentVolumeReclaimPolicy
+	// Phases are the managed PV phases, every phase is managed when empty
+	Phases []corev1.PersistentVolumePhase
+	// ReleasedTTL is how long a Retain PV may stay Released on a healthy node before it is collected,
+	// Released PVs are not collected when zero
+	ReleasedTTL time.Duration
+	// SweepInterval enables the periodic sweeper of all PVs, which replaces the per-PV requeue when non-zero
+	SweepInterval time.Duration
+	// SweepJitter is the maximum jitter factor added to the SweepInterval
+	SweepJitter float64
+	// MaxConcurrentReconciles is the number of PVs reconciled in parallel
+	MaxConcurrentReconciles int
+	// RateLimiterBaseDelay and RateLimiterMaxDelay bound the per-PV exponential backoff of failed reconciles
+	RateLimiterBaseDelay time.Duration
+	RateLimiterMaxDelay  time.Duration
+	// RateLimiterQPS and RateLimiterBurst size the overall token bucket of the requeues of all PVs
+	RateLimiterQPS   float64
+	RateLimiterBurst int
+	// Budget limits the rate of API mutating operations, nothing is limited when nil
+	Budget *MutationBudget
+	// NamespaceOptIn restricts the cleanup to PVs whose claim namespace carries the NamespaceOptInLabel
+	NamespaceOptIn bool
+	// Recorder records events on the PVs, events are not recorded when nil
+	Recorder record.EventRecorder
+	// Selector restricts the managed PVs to the ones the CEL expression matches, every PV is managed when nil
+	Selector *celpolicy.Expression
+	// OrphanRule is a CEL expression an orphaned PV must match to be deleted, every orphan is deleted when nil
+	OrphanRule *celpolicy.Expression
+	// InventoryMetrics enables the per-node local PV inventory metrics
+	InventoryMetrics bool
+	// InventoryNodeLabel aggregates the inventory metrics by the value of this node label instead of by node name
+	InventoryNodeLabel string
+	// APIReader reads the objects the cache only holds as metadata, the Client is used when nil
+	APIReader client.Reader
+	// Backup stores the manifests of the PVs and their claims before they are deleted, nothing is stored when nil
+	Backup backup.Store
+	// Notifier sends notifications about the cleanup activity, nothing is sent when nil
+	Notifier *notify.Notifier
+	// CloudEvents emits the cleanup lifecycle as CloudEvents, nothing is emitted when nil
+	CloudEvents *cloudevents.Emitter
+	// MaintenanceWindows restricts the deletions to the times a window is open, deletions are not restricted when empty
+	MaintenanceWindows window.Schedule
+	// RequireApproval holds every deletion until a reviewer approves the OrphanedVolume created for the PV
+	RequireApproval bool
+	// PauseConfigMap is the ConfigMap whose PauseKey pauses every deletion while it is true, at runtime.
+	// Deletions are never paused when its name is empty.
+	PauseConfigMap client.ObjectKey
+	// PolicyHook reviews every deletion with an external policy decision webhook, nothing is reviewed when nil
+	PolicyHook *policyhook.Client
+	// Audit receives a record of every destructive action, nothing is audited when nil
+	Audit audit.Sink
+	// Identity identifies this controller instance in the audit records
+	Identity string
+	// Tracer creates the reconcile spans, the global tracer provider is used when nil
+	Tracer trace.Tracer
+	// Clock is used for time based decisions, the wall clock is used when nil
+	Clock clock.PassiveClock
 
-var (
-	deletedPVsTotal = prometheus.NewCounterVec(
-		prometheus.CounterOpts{
-			Name: "local_pv_cleaner_deleted_pvs_total",
-			Help: "Total number of Orphaned PVs deleted",
-		},
-		[]string{"storage_class"},
-	)
-)
+	states     *stateTracker
+	statesOnce sync.Once
+	pause      *pauseSwitch
+	pauseOnce  sync.Once
 
-func init() {
-	metrics.Registry.MustRegister(deletedPVsTotal)
+	deletedNodes   *nodeLabelRecorder
+	nodeLabelsOnce sync.Once
 }
 
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
-// +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;watch;delete;patch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=volumeattachments,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;delete
+
+// reconcileOutcome is what a reconcile did with a PV, used as the decision label of the reconcile latency metric
+type reconcileOutcome string
+
+const (
+	outcomeSkip    reconcileOutcome = "skip"
+	outcomeRequeue reconcileOutcome = "requeue"
+	outcomeGrace   reconcileOutcome = "grace"
+	outcomeHold    reconcileOutcome = "hold"
+	outcomeDryRun  reconcileOutcome = "dry-run"
+	outcomeDelete  reconcileOutcome = "delete"
+	outcomeError   reconcileOutcome = "error"
+)
 
 func (r *PVCleanupController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	result, _, err := r.observeReconcile(ctx, req)
+	return result, err
+}
+
+// ReconcileOnce reconciles a single PV outside of a manager and returns the decision it made,
+// one of skip, requeue, grace, hold, dry-run, delete and error
+func (r *PVCleanupController) ReconcileOnce(ctx context.Context, name string) (string, error) {
+	_, outcome, err := r.observeReconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: name}})
+	return string(outcome), err
+}
+
+// observeReconcile traces and times a reconcile
+func (r *PVCleanupController) observeReconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, reconcileOutcome, error) {
+	start := time.Now()
+	ctx, span := r.startSpan(ctx, "Reconcile", attrPV.String(req.Name))
+	result, outcome, err := r.reconcile(ctx, req)
+	switch outcome {
+	case outcomeSkip, outcomeRequeue, outcomeGrace:
+		// the PV is no longer a deletion candidate, a previous review does not hold anymore
+		if expireErr := r.expireOrphanedVolume(ctx, req.Name); expireErr != nil {
+			err = expireErr
+		}
+	}
+	if err != nil {
+		outcome = outcomeError
+	}
+	span.SetAttributes(attrDecision.String(string(outcome)))
+	endSpan(span, err)
+	reconcileDecisionSeconds.WithLabelValues(string(outcome)).Observe(time.Since(start).Seconds())
+
+	return result, outcome, err
+}
+
+// reconcile decides what to do with the given PV and returns the decision it made
+func (r *PVCleanupController) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, reconcileOutcome, error) {
 	logger := log.FromContext(ctx)
 
 	var pv corev1.PersistentVolume
 	if err := r.Client.Get(ctx, req.NamespacedName, &pv); err != nil {
-		logger.Error(err, "PersistentVolume not found", "pv", req.Name)
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("PersistentVolume not found", "pv", req.Name)
+			r.tracker().forget(req.Name)
+			return ctrl.Result{}, outcomeSkip, nil
+		}
+		logger.Error(err, "Failed to get PersistentVolume", "pv", req.Name)
+		return ctrl.Result{}, outcomeError, err
 	}
+	trace.SpanFromContext(ctx).SetAttributes(attrStorageClass.String(pv.Spec.StorageClassName))
 
-	// skip if reclaim policy is Retain
-	if pv.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimRetain {
-		logger.V(1).Info("Skipping PV with reclaim policy not Retain", "policy", pv.Spec.PersistentVolumeReclaimPolicy)
-		return ctrl.Result{}, nil
+	d, err := r.Assess(ctx, pv)
+	if err != nil {
+		return ctrl.Result{}, outcomeError, err
 	}
-
-	// skip if storageClass is not in the user filters
-	if len(r.StorageClassNames) > 0 && !slices.Contains(r.StorageClassNames, pv.Spec.StorageClassName) {
-		logger.V(1).Info("Skipping PV due to unmatched StorageClass", "storageClass", pv.Spec.StorageClassName)
-		return ctrl.Result{}, nil
+	if d.Warning {
+		logger.Info("Policy expression failed on PV", "pv", pv.Name, "reason", d.Reason, "error", d.Message)
+		r.event(&pv, corev1.EventTypeWarning, "PolicyFailed", d.Message)
 	}
-
-	nodeName := getNodeNameFromAffinity(pv.Spec.NodeAffinity, r.NodeSelectorKeys)
-	if nodeName == "" {
-		logger.V(1).Info("No matching NodeAffinity found, skipping")
-		return ctrl.Result{}, nil
+	if d.Verdict == VerdictSkip {
+		return r.skip(ctx, pv, d.Reason)
+	}
+	if d.Reason == ReasonOrphanRule {
+		// node doesn't exist but the orphan rule keeps the PV, track it as an orphan that is not deleted
+		logger.V(1).Info("Orphaned PV kept by the orphan rule", "pv", pv.Name, "node", d.Node)
+		state := newPVState(pv, stateReasonOrphanRule, true, false)
+		state.since = r.orphanedSince(pv)
+		r.tracker().set(pv.Name, state, r.now())
+		return ctrl.Result{RequeueAfter: r.nodeRequeueAfter()}, outcomeRequeue, nil
 	}
 
-	var node corev1.Node
-	err := r.Client.Get(ctx, client.ObjectKey{Name: nodeName}, &node)
-	if err != nil {
-		// node doesn't exist, delete PV
+	// the guards are left to the deletion, which checks them against fresh facts
+	nodeName := d.Node
+	if d.Orphaned() {
+		// node doesn't exist, delete stale VolumeAttachments and the PV
+		r.reportOrphanFound(pv, "")
 		logger.V(1).Info("Node not found for PV, deleting PV", "pv", pv.Name, "node", nodeName)
-		if delErr := r.deleteOrphanedPV(ctx, pv); delErr != nil {
-			logger.Error(err, "Failed to delete orphaned PV", "pv", pv.Name, "node", nodeName)
-			return ctrl.Result{}, delErr
+		result, outcome, delErr := r.deleteOrphanedPV(ctx, pv)
+		if delErr != nil {
+			logger.Error(delErr, "Failed to delete orphaned PV", "pv", pv.Name, "node", nodeName)
+			return ctrl.Result{}, outcomeError, delErr
 		}
 
-		return ctrl.Result{}, nil
+		return result, outcome, nil
 	}
 
-	// node exists, requeue after X minutes
+	// node exists, collect the PV if it has been Released for too long
+	if r.ReleasedTTL > 0 && pv.Spec.PersistentVolumeReclaimPolicy == corev1.PersistentVolumeReclaimRetain {
+		return r.collectReleasedPV(ctx, pv)
+	}
+
+	// node exists, requeue after X minutes unless the sweeper checks it
 	logger.V(1).Info("Node exists Requeue PV", "pv", pv.Name, "node", nodeName)
-	return ctrl.Result{RequeueAfter: r.RequeueDuration}, nil
+	r.tracker().forget(pv.Name)
+	return ctrl.Result{RequeueAfter: r.nodeRequeueAfter()}, outcomeRequeue, nil
+}
+
+// skip records that the PV is skipped for the given reason. The CloudEvent is only emitted when the PV
+// becomes skipped, so that the resyncs of the unmanaged PVs do not flood the queue of the emitter.
+func (r *PVCleanupController) skip(ctx context.Context, pv corev1.PersistentVolume,
+	reason Reason) (ctrl.Result, reconcileOutcome, error) {
+	recordSkip(ctx, pv, reason)
+	if r.tracker().skip(pv.Name, reason) {
+		r.emit(pv, cloudevents.TypeSkipped, outcomeSkip, string(reason), nil)
+	}
+	return ctrl.Result{}, outcomeSkip, nil
 }
 
 // getNodeNameFromAffinity gets the nodeName based on the given nodeSelector keys
 func getNodeNameFromAffinity(affinity *corev1.VolumeNodeAffinity, nodeSelectorKeys []string) string {
-	if affinity == nil {
+	if affinity == nil || affinity.Required == nil {
 		return ""
 	}
 
@@ -123,36 +273,230 @@ func getNodeNameFromAffinity(affinity *corev1.VolumeNodeAffinity, nodeSelectorKe
 	return ""
 }
 
-// deleteOrphanedPV deletes the given PersistentVolume if the DryRun is not enabled
-func (r *PVCleanupController) deleteOrphanedPV(ctx context.Context, pv corev1.PersistentVolume) error {
+// deleteOrphanedPV deletes the given orphaned PersistentVolume
+func (r *PVCleanupController) deleteOrphanedPV(ctx context.Context,
+	pv corev1.PersistentVolume) (ctrl.Result, reconcileOutcome, error) {
+	ctx, span := r.startSpan(ctx, "deleteOrphanedPV", pvAttributes(pv)...)
+	result, outcome, err := r.deletePV(ctx, pv, causeOrphaned, actionForOrphan(pv))
+	span.SetAttributes(attrDecision.String(string(outcome)))
+	endSpan(span, err)
+
+	return result, outcome, err
+}
+
+// deletePV deletes the given PersistentVolume if no guard holds it and the DryRun is not enabled
+func (r *PVCleanupController) deletePV(ctx context.Context, pv corev1.PersistentVolume, cause deletionCause,
+	action orphanAction) (ctrl.Result, reconcileOutcome, error) {
 	logger := log.FromContext(ctx)
 
-	if !r.DryRun {
-		if err := r.Client.Delete(ctx, &pv); err != nil {
-			logger.Error(err, "Failed to delete PV", "pv", pv.Name)
-			return err
+	trip, err := r.checkGuards(ctx, pv, cause, action)
+	if err != nil {
+		logger.Error(err, "Failed to check guards of PV", "pv", pv.Name)
+		return ctrl.Result{}, outcomeError, err
+	}
+	if trip != nil {
+		return r.hold(ctx, pv, cause, trip)
+	}
+
+	if r.DryRun {
+		logger.Info("DryRun enabled, skipping deletion of PV", "pv", pv.Name, "cause", cause, "action", action)
+		r.event(&pv, corev1.EventTypeNormal, "DryRunDelete", fmt.Sprintf("PV would be deleted (%s)", cause))
+		dryRunWouldDeleteTotal.WithLabelValues(pv.Spec.StorageClassName, string(cause)).Inc()
+		if state, ok := r.tracker().get(pv.Name); !ok || state.reason != stateReasonDryRun {
+			r.notify(pv, notify.KindDryRun, fmt.Sprintf("PV would be deleted (%s)", cause))
 		}
-		logger.Info("Deleted orphaned PV", "pv", pv.Name)
-		deletedPVsTotal.WithLabelValues(pv.Spec.StorageClassName).Inc()
+		r.audit(ctx, pv, audit.ActionDryRun, cause, "PV would be deleted", r.auditEvidence(pv, cause))
+		r.trackPending(pv, cause, stateReasonDryRun)
+		return ctrl.Result{}, outcomeDryRun, nil
+	}
+
+	snapshot, err := r.backupPV(ctx, pv, cause)
+	if err != nil {
+		return ctrl.Result{}, outcomeError, err
+	}
+	if err := r.Budget.Wait(ctx, "delete-pv"); err != nil {
+		return ctrl.Result{}, outcomeError, err
+	}
+	// the deletions may have been paused while waiting for the budget
+	if trip, err = r.pauseHold(ctx); err != nil {
+		return ctrl.Result{}, outcomeError, err
+	}
+	if trip != nil {
+		return r.hold(ctx, pv, cause, trip)
+	}
+	// the stale VolumeAttachments are only deleted once nothing holds the PV anymore
+	if cause == causeOrphaned {
+		if err := r.cleanupVolumeAttachments(ctx, pv); err != nil {
+			logger.Error(err, "Failed to clean up VolumeAttachments of orphaned PV", "pv", pv.Name)
+			return ctrl.Result{}, outcomeError, err
+		}
+	}
+	evidence := r.auditEvidence(pv, cause)
+	if snapshot != "" {
+		evidence["backup"] = snapshot
+	}
+	if action == actionReclaim {
+		err = r.reclaimPV(ctx, &pv)
+	} else {
+		err = r.Client.Delete(ctx, &pv)
+	}
+	if err != nil {
+		logger.Error(err, "Failed to delete PV", "pv", pv.Name, "action", action)
+		deleteFailuresTotal.WithLabelValues(pv.Spec.StorageClassName, errorClass(err)).Inc()
+		r.notify(pv, notify.KindDeleteFailed, err.Error())
+		r.trackPending(pv, cause, stateReasonDeleteFailed)
+		return ctrl.Result{}, outcomeError, err
+	}
+	if action == actionForceDelete {
+		if err := r.stripProvisionerFinalizer(ctx, &pv); err != nil {
+			return ctrl.Result{}, outcomeError, err
+		}
+	}
+	evidence["action"] = string(action)
+	if action == actionReclaim {
+		logger.Info("Reclaimed PV, its provisioner deletes the volume", "pv", pv.Name, "cause", cause)
+		r.audit(ctx, pv, audit.ActionReclaim, cause, "Reclaim policy switched to Delete", evidence)
+		r.notify(pv, notify.KindDeleted, fmt.Sprintf("PV handed over to its provisioner for deletion (%s)", cause))
+		r.event(&pv, corev1.EventTypeNormal, "Reclaimed",
+			fmt.Sprintf("Reclaim policy switched to Delete, the provisioner deletes the volume (%s)", cause))
 	} else {
-		logger.Info("DryRun enabled, skipping deletion of PV", "pv", pv.Name)
+		logger.Info("Deleted PV", "pv", pv.Name, "cause", cause, "action", action)
+		r.audit(ctx, pv, audit.ActionDelete, cause, "PV deleted", evidence)
+		r.notify(pv, notify.KindDeleted, fmt.Sprintf("PV deleted (%s)", cause))
+		r.event(&pv, corev1.EventTypeNormal, "Deleted", fmt.Sprintf("PV deleted (%s)", cause))
+	}
+	r.emit(pv, cloudevents.TypeDeleted, outcomeDelete, string(cause), evidence)
+	switch cause {
+	case causeOrphaned:
+		deletedPVsTotal.WithLabelValues(pv.Spec.StorageClassName).Inc()
+		orphanDeletionLatencySeconds.WithLabelValues(pv.Spec.StorageClassName).
+			Observe(r.now().Sub(r.orphanedSince(pv)).Seconds())
+	case causeReleasedTTL:
+		collectedReleasedPVsTotal.WithLabelValues(pv.Spec.StorageClassName).Inc()
 	}
+	r.tracker().forget(pv.Name)
+	r.orphanedVolumeDeleted(ctx, pv)
 
-	return nil
+	return ctrl.Result{}, outcomeDelete, nil
+}
+
+// hold records that the given guard blocked the deletion of the PV
+func (r *PVCleanupController) hold(ctx context.Context, pv corev1.PersistentVolume, cause deletionCause,
+	trip *guardTrip) (ctrl.Result, reconcileOutcome, error) {
+	log.FromContext(ctx).Info("Guard blocked deletion of PV", "pv", pv.Name, "guard", trip.Guard,
+		"message", trip.Message)
+	guardTripsTotal.WithLabelValues(pv.Spec.StorageClassName, trip.Guard).Inc()
+	eventType := corev1.EventTypeNormal
+	if trip.Warning {
+		eventType = corev1.EventTypeWarning
+	}
+	r.event(&pv, eventType, "DeletionBlocked", trip.Message)
+	evidence := r.auditEvidence(pv, cause)
+	evidence["guard"] = trip.Guard
+	r.audit(ctx, pv, audit.ActionGuardTrip, cause, trip.Message, evidence)
+	evidence["message"] = trip.Message
+	r.emit(pv, cloudevents.TypeGuardTripped, outcomeHold, trip.Guard, evidence)
+	r.trackPending(pv, cause, trip.Guard)
+	return ctrl.Result{RequeueAfter: trip.RequeueAfter}, outcomeHold, nil
+}
+
+// trackPending records an orphaned PV that was not deleted for the given reason
+func (r *PVCleanupController) trackPending(pv corev1.PersistentVolume, cause deletionCause, reason string) {
+	if cause != causeOrphaned {
+		r.tracker().forget(pv.Name)
+		return
+	}
+	state := newPVState(pv, reason, true, false)
+	state.since = r.orphanedSince(pv)
+	r.tracker().set(pv.Name, state, r.now())
+}
+
+// IndexFields registers the field indexes the controller reads through
+func IndexFields(ctx context.Context, indexer client.FieldIndexer) error {
+	return indexer.IndexField(ctx, &storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *PVCleanupController) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&corev1.PersistentVolume{}).
+	if err := IndexFields(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+
+	if err := metrics.Registry.Register(newStateCollector(r.tracker())); err != nil {
+		return err
+	}
+
+	nodeInformer, err := mgr.GetCache().GetInformer(context.Background(), newMetadata(nodeGVK))
+	if err != nil {
+		return err
+	}
+	if _, err := nodeInformer.AddEventHandler(r.nodeLabelsEventHandler()); err != nil {
+		return err
+	}
+
+	if r.InventoryMetrics {
+		if err := metrics.Registry.Register(newInventoryCollector(mgr.GetCache(), r.NodeSelectorKeys,
+			r.StorageClassNames, r.InventoryNodeLabel)); err != nil {
+			return err
+		}
+	}
+
+	blder := ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.PersistentVolume{}, builder.WithPredicates(pvPredicate()))
+
+	if r.RequireApproval {
+		blder = blder.Watches(&v1alpha1.OrphanedVolume{}, enqueueOrphanedVolumePV())
+	}
+
+	if len(r.MaintenanceWindows) > 0 {
+		for _, collector := range newMaintenanceWindowMetrics(r) {
+			if err := metrics.Registry.Register(collector); err != nil {
+				return err
+			}
+		}
+	}
+
+	if r.PauseConfigMap.Name != "" {
+		ctx := log.IntoContext(context.Background(), mgr.GetLogger().WithName("pause"))
+		informer, err := mgr.GetCache().GetInformer(ctx, &corev1.ConfigMap{})
+		if err != nil {
+			return err
+		}
+		if _, err := informer.AddEventHandler(r.pauseEventHandler(ctx)); err != nil {
+			return err
+		}
+	}
+
+	if r.SweepInterval > 0 || len(r.MaintenanceWindows) > 0 || r.PauseConfigMap.Name != "" {
+		events := make(chan event.GenericEvent)
+		if r.SweepInterval > 0 {
+			if err := mgr.Add(&sweeper{
+				controller: r,
+				interval:   r.SweepInterval,
+				jitter:     r.SweepJitter,
+				events:     events,
+			}); err != nil {
+				return err
+			}
+		}
+		if len(r.MaintenanceWindows) > 0 {
+			if err := mgr.Add(&windowOpener{controller: r, events: events}); err != nil {
+				return err
+			}
+		}
+		if r.PauseConfigMap.Name != "" {
+			if err := mgr.Add(&pauseResumer{controller: r, events: events}); err != nil {
+				return err
+			}
+		}
+		blder = blder.WatchesRawSource(source.Channel(events, &handler.EnqueueRequestForObject{}))
+	}
+
+	return blder.
 		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 1,
-		}).
-		WithEventFilter(predicate.Funcs{
-			CreateFunc:  func(e event.CreateEvent) bool { return true },
-			UpdateFunc:  func(e event.UpdateEvent) bool { return false },
-			DeleteFunc:  func(e event.DeleteEvent) bool { return false },
-			GenericFunc: func(e event.GenericEvent) bool { return false },
+			MaxConcurrentReconciles: max(r.MaxConcurrentReconciles, 1),
+			RateLimiter: newRateLimiter(r.RateLimiterBaseDelay, r.RateLimiterMaxDelay,
+				r.RateLimiterQPS, r.RateLimiterBurst),
 		}).
 		Named("local-pv-cleaner").
 		Complete(r)
@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -169,7 +170,8 @@ func TestPVCleanupController_deleteOrphanedPVs(t *testing.T) {
 	// Run tests
 	for _, tt := range tests {
 		ctx := context.Background()
-		fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(tt.objects...).Build()
+		fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(tt.objects...).
+			WithIndex(&storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV).Build()
 
 		t.Run(tt.name, func(t *testing.T) {
 			r := &PVCleanupController{
@@ -177,7 +179,7 @@ func TestPVCleanupController_deleteOrphanedPVs(t *testing.T) {
 				DryRun: tt.args.DryRun,
 			}
 
-			err := r.deleteOrphanedPV(ctx, tt.orphanedPV[0])
+			_, _, err := r.deleteOrphanedPV(ctx, tt.orphanedPV[0])
 
 			if tt.wantErr {
 				assert.Error(t, err, "Expected an error but got none")
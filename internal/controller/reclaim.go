@@ -0,0 +1,157 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/audit"
+)
+
+// provisionerFinalizer is added by the CSI external-provisioner to PVs it has to delete
+const provisionerFinalizer = "external-provisioner.volume.kubernetes.io/finalizer"
+
+// orphanAction is the action taken on an orphaned PV
+type orphanAction string
+
+const (
+	// actionDelete deletes the PV object, the backing storage is gone together with its node
+	actionDelete orphanAction = "delete"
+	// actionForceDelete deletes the PV object and strips the external-provisioner finalizer,
+	// because the external deleter can never reach the node to delete the volume
+	actionForceDelete orphanAction = "force-delete"
+	// actionReclaim switches the reclaim policy of a Released PV on a healthy node to Delete,
+	// so that its provisioner deletes the backing volume together with the PV
+	actionReclaim orphanAction = "reclaim"
+)
+
+// ParseReclaimPolicies converts the given names to the reclaim policies the controller can manage
+func ParseReclaimPolicies(names []string) ([]corev1.PersistentVolumeReclaimPolicy, error) {
+	policies := make([]corev1.PersistentVolumeReclaimPolicy, 0, len(names))
+	for _, name := range names {
+		policy := corev1.PersistentVolumeReclaimPolicy(name)
+		switch policy {
+		case corev1.PersistentVolumeReclaimRetain, corev1.PersistentVolumeReclaimDelete:
+			policies = append(policies, policy)
+		default:
+			return nil, fmt.Errorf("unsupported reclaim policy %q, expected Retain or Delete", name)
+		}
+	}
+
+	return policies, nil
+}
+
+// ParsePhases converts the given names to the PV phases the controller can manage
+func ParsePhases(names []string) ([]corev1.PersistentVolumePhase, error) {
+	phases := make([]corev1.PersistentVolumePhase, 0, len(names))
+	for _, name := range names {
+		phase := corev1.PersistentVolumePhase(name)
+		switch phase {
+		case corev1.VolumeBound, corev1.VolumeReleased, corev1.VolumeFailed, corev1.VolumeAvailable:
+			phases = append(phases, phase)
+		default:
+			return nil, fmt.Errorf("unsupported PV phase %q, expected Bound, Released, Failed or Available", name)
+		}
+	}
+
+	return phases, nil
+}
+
+// managesReclaimPolicy reports whether the PV reclaim policy is managed, only Retain is managed by default
+func (p Policy) managesReclaimPolicy(pv corev1.PersistentVolume) bool {
+	policy := pv.Spec.PersistentVolumeReclaimPolicy
+	if len(p.ReclaimPolicies) == 0 {
+		return policy == corev1.PersistentVolumeReclaimRetain
+	}
+
+	for _, managed := range p.ReclaimPolicies {
+		if managed == policy {
+			return true
+		}
+	}
+	return false
+}
+
+// managesPhase reports whether the PV phase is managed, every phase is managed by default
+func (p Policy) managesPhase(pv corev1.PersistentVolume) bool {
+	if len(p.Phases) == 0 {
+		return true
+	}
+
+	for _, managed := range p.Phases {
+		if managed == pv.Status.Phase {
+			return true
+		}
+	}
+	return false
+}
+
+// actionForOrphan returns the action tailored to the reclaim policy and phase of an orphaned PV
+func actionForOrphan(pv corev1.PersistentVolume) orphanAction {
+	if pv.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimDelete {
+		return actionDelete
+	}
+
+	switch pv.Status.Phase {
+	case corev1.VolumeReleased, corev1.VolumeFailed:
+		// the external deleter has tried and failed to delete the volume on the missing node
+		return actionForceDelete
+	default:
+		// give the external deleter a chance, the PV is force deleted once it is Released or Failed
+		return actionDelete
+	}
+}
+
+// stripProvisionerFinalizer removes the external-provisioner finalizer from a PV that is being deleted
+func (r *PVCleanupController) stripProvisionerFinalizer(ctx context.Context, pv *corev1.PersistentVolume) error {
+	logger := log.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(pv, provisionerFinalizer) {
+		return nil
+	}
+
+	if err := r.Budget.Wait(ctx, "strip-finalizer"); err != nil {
+		return err
+	}
+	patch := client.MergeFrom(pv.DeepCopy())
+	controllerutil.RemoveFinalizer(pv, provisionerFinalizer)
+	if err := r.Client.Patch(ctx, pv, patch); client.IgnoreNotFound(err) != nil {
+		logger.Error(err, "Failed to strip finalizer of PV", "pv", pv.Name, "finalizer", provisionerFinalizer)
+		return err
+	}
+	logger.Info("Stripped finalizer of orphaned PV", "pv", pv.Name, "finalizer", provisionerFinalizer)
+	strippedFinalizersTotal.WithLabelValues(pv.Spec.StorageClassName).Inc()
+	evidence := r.auditEvidence(*pv, causeOrphaned)
+	evidence["finalizer"] = provisionerFinalizer
+	r.audit(ctx, *pv, audit.ActionFinalizerStrip, causeOrphaned, "Stripped finalizer "+provisionerFinalizer, evidence)
+
+	return nil
+}
+
+// reclaimPV hands a Released PV over to its provisioner by switching its reclaim policy to Delete.
+// The optimistic lock makes sure the PV did not change since it was evaluated.
+func (r *PVCleanupController) reclaimPV(ctx context.Context, pv *corev1.PersistentVolume) error {
+	patch := client.MergeFromWithOptions(pv.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimDelete
+	return r.Client.Patch(ctx, pv, patch)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseReclaimPolicies(t *testing.T) {
+	policies, err := ParseReclaimPolicies([]string{"Retain", "Delete"})
+	require.NoError(t, err)
+	assert.Equal(t, []corev1.PersistentVolumeReclaimPolicy{
+		corev1.PersistentVolumeReclaimRetain, corev1.PersistentVolumeReclaimDelete,
+	}, policies)
+
+	_, err = ParseReclaimPolicies([]string{"Recycle"})
+	assert.Error(t, err)
+}
+
+func TestParsePhases(t *testing.T) {
+	phases, err := ParsePhases([]string{"Released", "Failed"})
+	require.NoError(t, err)
+	assert.Equal(t, []corev1.PersistentVolumePhase{corev1.VolumeReleased, corev1.VolumeFailed}, phases)
+
+	_, err = ParsePhases([]string{"Pending"})
+	assert.Error(t, err)
+}
+
+func TestPolicy_managesPV(t *testing.T) {
+	newPV := func(policy corev1.PersistentVolumeReclaimPolicy, phase corev1.PersistentVolumePhase) corev1.PersistentVolume {
+		return corev1.PersistentVolume{
+			Spec:   corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: policy},
+			Status: corev1.PersistentVolumeStatus{Phase: phase},
+		}
+	}
+
+	defaults := Policy{}
+	assert.True(t, defaults.managesReclaimPolicy(newPV(corev1.PersistentVolumeReclaimRetain, corev1.VolumeBound)))
+	assert.False(t, defaults.managesReclaimPolicy(newPV(corev1.PersistentVolumeReclaimDelete, corev1.VolumeBound)))
+	assert.True(t, defaults.managesPhase(newPV(corev1.PersistentVolumeReclaimRetain, corev1.VolumePending)))
+
+	configured := Policy{
+		ReclaimPolicies: []corev1.PersistentVolumeReclaimPolicy{corev1.PersistentVolumeReclaimDelete},
+		Phases:          []corev1.PersistentVolumePhase{corev1.VolumeFailed},
+	}
+	assert.True(t, configured.managesReclaimPolicy(newPV(corev1.PersistentVolumeReclaimDelete, corev1.VolumeFailed)))
+	assert.False(t, configured.managesReclaimPolicy(newPV(corev1.PersistentVolumeReclaimRetain, corev1.VolumeFailed)))
+	assert.True(t, configured.managesPhase(newPV(corev1.PersistentVolumeReclaimDelete, corev1.VolumeFailed)))
+	assert.False(t, configured.managesPhase(newPV(corev1.PersistentVolumeReclaimDelete, corev1.VolumeBound)))
+}
+
+func TestActionForOrphan(t *testing.T) {
+	var tests = []struct {
+		name           string
+		policy         corev1.PersistentVolumeReclaimPolicy
+		phase          corev1.PersistentVolumePhase
+		expectedAction orphanAction
+	}{
+		{name: "Retain Bound", policy: corev1.PersistentVolumeReclaimRetain, phase: corev1.VolumeBound, expectedAction: actionDelete},
+		{name: "Retain Failed", policy: corev1.PersistentVolumeReclaimRetain, phase: corev1.VolumeFailed, expectedAction: actionDelete},
+		{name: "Delete Bound", policy: corev1.PersistentVolumeReclaimDelete, phase: corev1.VolumeBound, expectedAction: actionDelete},
+		{name: "Delete Released", policy: corev1.PersistentVolumeReclaimDelete, phase: corev1.VolumeReleased, expectedAction: actionForceDelete},
+		{name: "Delete Failed", policy: corev1.PersistentVolumeReclaimDelete, phase: corev1.VolumeFailed, expectedAction: actionForceDelete},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pv := corev1.PersistentVolume{
+				Spec:   corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: tt.policy},
+				Status: corev1.PersistentVolumeStatus{Phase: tt.phase},
+			}
+			assert.Equal(t, tt.expectedAction, actionForOrphan(pv))
+		})
+	}
+}
+
+func TestPVCleanupController_deleteOrphanedPV_forceDelete(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+
+	ctx := context.Background()
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Finalizers: []string{provisionerFinalizer}},
+		Spec:       corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete},
+		Status:     corev1.PersistentVolumeStatus{Phase: corev1.VolumeFailed},
+	}
+	fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(pv).WithStatusSubresource(pv).
+		WithIndex(&storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV).Build()
+
+	r := &PVCleanupController{Client: fakeClient}
+
+	_, _, err := r.deleteOrphanedPV(ctx, *pv)
+	require.NoError(t, err)
+	assert.Error(t, fakeClient.Get(ctx, client.ObjectKey{Name: pv.Name}, &corev1.PersistentVolume{}),
+		"Expected PV to be deleted once the finalizer is stripped")
+}
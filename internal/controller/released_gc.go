@@ -0,0 +1,111 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ReleasedAtAnnotation records, as an RFC3339 timestamp, when the controller first saw the PV Released
+const ReleasedAtAnnotation = annotationPrefix + "released-at"
+
+// deletionCause is the reason a PV gets deleted
+type deletionCause string
+
+const (
+	// causeOrphaned is used for PVs whose node no longer exists
+	causeOrphaned deletionCause = "orphaned"
+	// causeReleasedTTL is used for PVs that stayed Released longer than the TTL
+	causeReleasedTTL deletionCause = "released-ttl"
+)
+
+// releasedAt returns when the PV was released, from the annotation or else from the last phase transition
+func releasedAt(pv corev1.PersistentVolume) (time.Time, bool) {
+	if value, ok := pv.Annotations[ReleasedAtAnnotation]; ok {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, true
+		}
+	}
+	if pv.Status.LastPhaseTransitionTime != nil {
+		return pv.Status.LastPhaseTransitionTime.Time, false
+	}
+
+	return time.Time{}, false
+}
+
+// collectReleasedPV tracks how long a PV on a healthy node has been Released and hands it over to its provisioner
+// for deletion once the TTL expired
+func (r *PVCleanupController) collectReleasedPV(ctx context.Context,
+	pv corev1.PersistentVolume) (ctrl.Result, reconcileOutcome, error) {
+	logger := log.FromContext(ctx)
+
+	if pv.Status.Phase != corev1.VolumeReleased {
+		if _, ok := pv.Annotations[ReleasedAtAnnotation]; ok && !r.DryRun {
+			// the PV got bound again, forget the release
+			if err := r.Budget.Wait(ctx, "annotate-pv"); err != nil {
+				return ctrl.Result{}, outcomeError, err
+			}
+			patch := client.MergeFrom(pv.DeepCopy())
+			delete(pv.Annotations, ReleasedAtAnnotation)
+			if err := r.Client.Patch(ctx, &pv, patch); err != nil {
+				logger.Error(err, "Failed to clear release time of PV", "pv", pv.Name)
+				return ctrl.Result{}, outcomeError, err
+			}
+		}
+		r.tracker().forget(pv.Name)
+		return ctrl.Result{RequeueAfter: r.nodeRequeueAfter()}, outcomeRequeue, nil
+	}
+
+	now := r.now()
+	since, tracked := releasedAt(pv)
+	if since.IsZero() {
+		since = now
+	}
+	if !tracked && !r.DryRun {
+		if err := r.Budget.Wait(ctx, "annotate-pv"); err != nil {
+			return ctrl.Result{}, outcomeError, err
+		}
+		patch := client.MergeFrom(pv.DeepCopy())
+		if pv.Annotations == nil {
+			pv.Annotations = map[string]string{}
+		}
+		pv.Annotations[ReleasedAtAnnotation] = since.UTC().Format(time.RFC3339)
+		if err := r.Client.Patch(ctx, &pv, patch); err != nil {
+			logger.Error(err, "Failed to record release time of PV", "pv", pv.Name)
+			return ctrl.Result{}, outcomeError, err
+		}
+		logger.V(1).Info("Recorded release time of PV", "pv", pv.Name, "releasedAt", since)
+	}
+
+	if remaining := since.Add(r.ReleasedTTL).Sub(now); remaining > 0 {
+		logger.V(1).Info("Released PV within TTL, requeue", "pv", pv.Name, "remaining", remaining)
+		state := newPVState(pv, stateReasonReleasedTTL, false, true)
+		state.since = since
+		r.tracker().set(pv.Name, state, now)
+		return ctrl.Result{RequeueAfter: remaining}, outcomeGrace, nil
+	}
+
+	// deleting the PV object alone would leak the backing volume of a Retain PV, its provisioner deletes both
+	logger.V(1).Info("Released PV exceeded TTL, reclaiming PV", "pv", pv.Name, "releasedAt", since)
+	return r.deletePV(ctx, pv, causeReleasedTTL, actionReclaim)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kavinraja-g/local-pv-cleaner/internal/audit"
+)
+
+func TestPVCleanupController_collectReleasedPV(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	newPV := func(phase corev1.PersistentVolumePhase, annotations map[string]string) *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Annotations: annotations},
+			Spec:       corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain},
+			Status:     corev1.PersistentVolumeStatus{Phase: phase},
+		}
+	}
+
+	var tests = []struct {
+		name               string
+		pv                 *corev1.PersistentVolume
+		dryRun             bool
+		wantReclaimed      bool
+		wantReleasedAt     string
+		wantRequeueAfter   time.Duration
+		wantNoReleaseTrack bool
+	}{
+		{
+			name:             "Start tracking a newly Released PV",
+			pv:               newPV(corev1.VolumeReleased, nil),
+			wantReleasedAt:   "2025-03-01T12:00:00Z",
+			wantRequeueAfter: 24 * time.Hour,
+		},
+		{
+			name:             "Released PV within TTL",
+			pv:               newPV(corev1.VolumeReleased, map[string]string{ReleasedAtAnnotation: "2025-03-01T00:00:00Z"}),
+			wantReleasedAt:   "2025-03-01T00:00:00Z",
+			wantRequeueAfter: 12 * time.Hour,
+		},
+		{
+			name:           "Released PV past TTL is handed over to its provisioner",
+			pv:             newPV(corev1.VolumeReleased, map[string]string{ReleasedAtAnnotation: "2025-02-27T00:00:00Z"}),
+			wantReclaimed:  true,
+			wantReleasedAt: "2025-02-27T00:00:00Z",
+		},
+		{
+			name:               "Bound PV forgets its release time",
+			pv:                 newPV(corev1.VolumeBound, map[string]string{ReleasedAtAnnotation: "2025-02-27T00:00:00Z"}),
+			wantRequeueAfter:   15 * time.Minute,
+			wantNoReleaseTrack: true,
+		},
+		{
+			name:               "DryRun does not record the release time",
+			pv:                 newPV(corev1.VolumeReleased, nil),
+			dryRun:             true,
+			wantRequeueAfter:   24 * time.Hour,
+			wantNoReleaseTrack: true,
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(tt.pv).Build()
+
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &recordingSink{}
+			r := &PVCleanupController{
+				Client:          fakeClient,
+				Audit:           sink,
+				DryRun:          tt.dryRun,
+				Clock:           clocktesting.NewFakePassiveClock(now),
+				RequeueDuration: 15 * time.Minute,
+				ReleasedTTL:     24 * time.Hour,
+			}
+
+			result, _, err := r.collectReleasedPV(ctx, *tt.pv)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRequeueAfter, result.RequeueAfter)
+
+			var got corev1.PersistentVolume
+			err = fakeClient.Get(ctx, client.ObjectKey{Name: tt.pv.Name}, &got)
+			require.NoError(t, err, "Expected the PV to be left to its provisioner")
+			wantPolicy := corev1.PersistentVolumeReclaimRetain
+			if tt.wantReclaimed {
+				wantPolicy = corev1.PersistentVolumeReclaimDelete
+			}
+			assert.Equal(t, wantPolicy, got.Spec.PersistentVolumeReclaimPolicy)
+			if tt.wantReclaimed {
+				require.Len(t, sink.records, 1)
+				assert.Equal(t, audit.ActionReclaim, sink.records[0].Action)
+				assert.Equal(t, "reclaim", sink.records[0].Evidence["action"])
+			}
+			if tt.wantNoReleaseTrack {
+				assert.NotContains(t, got.Annotations, ReleasedAtAnnotation)
+			} else {
+				assert.Equal(t, tt.wantReleasedAt, got.Annotations[ReleasedAtAnnotation])
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// RetainUntilAnnotation holds an RFC3339 timestamp until which an orphaned PV must not be deleted.
+// It is honoured on the PV, its PVC and the namespace of its PVC.
+const RetainUntilAnnotation = annotationPrefix + "retain-until"
+
+// retentionSource is an object that may carry the RetainUntilAnnotation
+type retentionSource struct {
+	kind        string
+	name        string
+	annotations map[string]string
+}
+
+// retentionSources returns the PV together with its PVC and namespace, when they still exist
+func retentionSources(pv corev1.PersistentVolume, facts Facts) []retentionSource {
+	sources := []retentionSource{{kind: "PersistentVolume", name: pv.Name, annotations: pv.Annotations}}
+
+	claim := pv.Spec.ClaimRef
+	if claim == nil {
+		return sources
+	}
+	if facts.Claim != nil {
+		sources = append(sources, retentionSource{
+			kind: "PersistentVolumeClaim", name: claim.Namespace + "/" + claim.Name, annotations: facts.Claim.Annotations,
+		})
+	}
+	if facts.Namespace != nil {
+		sources = append(sources, retentionSource{
+			kind: "Namespace", name: facts.Namespace.Name, annotations: facts.Namespace.Annotations,
+		})
+	}
+
+	return sources
+}
+
+// retentionHold trips when any retention source holds the PV past the time of the facts.
+// The latest retain-until timestamp wins, and a malformed timestamp holds the PV until it is fixed,
+// such a trip has no RequeueAfter.
+func retentionHold(pv corev1.PersistentVolume, facts Facts) *guardTrip {
+	var hold *guardTrip
+	var holdUntil time.Time
+	for _, src := range retentionSources(pv, facts) {
+		value, ok := src.annotations[RetainUntilAnnotation]
+		if !ok {
+			continue
+		}
+
+		until, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return &guardTrip{
+				Guard: guardRetentionHold,
+				Message: fmt.Sprintf("Invalid %s annotation %q on %s %s, holding PV until it is fixed",
+					RetainUntilAnnotation, value, src.kind, src.name),
+				Warning: true,
+			}
+		}
+
+		if until.After(facts.Now) && until.After(holdUntil) {
+			holdUntil = until
+			hold = &guardTrip{
+				Guard: guardRetentionHold,
+				Message: fmt.Sprintf("PV is retained until %s by %s %s",
+					until.Format(time.RFC3339), src.kind, src.name),
+				RequeueAfter: until.Sub(facts.Now),
+			}
+		}
+	}
+
+	return hold
+}
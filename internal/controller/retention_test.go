@@ -0,0 +1,174 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRetentionHold(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	claimRef := &corev1.ObjectReference{Namespace: "team-a", Name: "data"}
+	retainUntil := func(until string) map[string]string {
+		return map[string]string{RetainUntilAnnotation: until}
+	}
+
+	var tests = []struct {
+		name             string
+		facts            Facts
+		pv               corev1.PersistentVolume
+		wantHold         bool
+		wantWarning      bool
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:     "No retention annotation",
+			pv:       corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv-1"}},
+			wantHold: false,
+		},
+		{
+			name: "Retained by PV",
+			pv: corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{
+				Name: "pv-1", Annotations: retainUntil("2025-03-01T13:00:00Z"),
+			}},
+			wantHold:         true,
+			wantRequeueAfter: time.Hour,
+		},
+		{
+			name: "Expired retention",
+			pv: corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{
+				Name: "pv-1", Annotations: retainUntil("2025-03-01T11:00:00Z"),
+			}},
+			wantHold: false,
+		},
+		{
+			name: "Latest hold wins across PV, PVC and namespace",
+			facts: Facts{
+				Claim: &metav1.ObjectMeta{
+					Namespace: "team-a", Name: "data", Annotations: retainUntil("2025-03-01T14:00:00Z"),
+				},
+				Namespace: &metav1.ObjectMeta{Name: "team-a", Annotations: retainUntil("2025-03-02T12:00:00Z")},
+			},
+			pv: corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1", Annotations: retainUntil("2025-03-01T13:00:00Z")},
+				Spec:       corev1.PersistentVolumeSpec{ClaimRef: claimRef},
+			},
+			wantHold:         true,
+			wantRequeueAfter: 24 * time.Hour,
+		},
+		{
+			name: "Malformed timestamp holds the PV",
+			pv: corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{
+				Name: "pv-1", Annotations: retainUntil("next tuesday"),
+			}},
+			wantHold:    true,
+			wantWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.facts.Now = now
+			trip := retentionHold(tt.pv, tt.facts)
+			if !tt.wantHold {
+				assert.Nil(t, trip)
+				return
+			}
+			require.NotNil(t, trip)
+			assert.Equal(t, guardRetentionHold, trip.Guard)
+			assert.Equal(t, tt.wantWarning, trip.Warning)
+			assert.Equal(t, tt.wantRequeueAfter, trip.RequeueAfter)
+		})
+	}
+}
+
+func TestPVCleanupController_checkGuards(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+
+	ctx := context.Background()
+	pv := corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+		Spec: corev1.PersistentVolumeSpec{
+			ClaimRef: &corev1.ObjectReference{Namespace: "team-a", Name: "data"},
+		},
+	}
+	fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "team-a", Name: "data", Annotations: map[string]string{RetainUntilAnnotation: "soon"},
+		}},
+	).Build()
+
+	r := &PVCleanupController{
+		Client:          fakeClient,
+		Clock:           clocktesting.NewFakePassiveClock(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)),
+		RequeueDuration: 15 * time.Minute,
+	}
+
+	trip, err := r.checkGuards(ctx, pv, causeOrphaned, actionDelete)
+	require.NoError(t, err)
+	require.NotNil(t, trip)
+	assert.True(t, trip.Warning)
+	assert.Equal(t, 15*time.Minute, trip.RequeueAfter, "Expected a malformed hold to be retried after the requeue duration")
+}
+
+func TestPVCleanupController_deleteOrphanedPV_retentionHold(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	pv := &corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{
+		Name: "pv-1", Annotations: map[string]string{RetainUntilAnnotation: "2025-03-01T12:30:00Z"},
+	}}
+	fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(pv).
+		WithIndex(&storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV).Build()
+	recorder := record.NewFakeRecorder(1)
+	fakeClock := clocktesting.NewFakePassiveClock(now)
+
+	r := &PVCleanupController{
+		Client:   fakeClient,
+		Recorder: recorder,
+		Clock:    fakeClock,
+	}
+
+	result, _, err := r.deleteOrphanedPV(ctx, *pv)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, result.RequeueAfter)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: pv.Name}, &corev1.PersistentVolume{}))
+	assert.Contains(t, <-recorder.Events, "DeletionBlocked")
+
+	// the PV becomes eligible once the hold passes
+	fakeClock.SetTime(now.Add(time.Hour))
+	result, _, err = r.deleteOrphanedPV(ctx, *pv)
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+	assert.Error(t, fakeClient.Get(ctx, client.ObjectKey{Name: pv.Name}, &corev1.PersistentVolume{}))
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Reasons a tracked PV is not deleted yet, used as the reason label of the orphaned and grace period gauges
+const (
+	stateReasonReleasedTTL  = string(ReasonReleasedTTL)
+	stateReasonOrphanRule   = string(ReasonOrphanRule)
+	stateReasonDryRun       = "dry-run"
+	stateReasonDeleteFailed = "delete-failed"
+)
+
+// pvState is what the controller last decided about a PV that is waiting for its deletion
+type pvState struct {
+	storageClass string
+	reason       string
+	// orphan is set for PVs whose node is missing
+	orphan bool
+	// grace is set for PVs waiting out a grace period
+	grace bool
+	// capacity is the storage capacity of the PV in bytes
+	capacity int64
+	// since is when the PV was first seen orphaned or released
+	since time.Time
+}
+
+// newPVState returns the state of the given PV
+func newPVState(pv corev1.PersistentVolume, reason string, orphan, grace bool) pvState {
+	var capacity int64
+	if storage, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok {
+		capacity = storage.Value()
+	}
+
+	return pvState{
+		storageClass: pv.Spec.StorageClassName,
+		reason:       reason,
+		orphan:       orphan,
+		grace:        grace,
+		capacity:     capacity,
+	}
+}
+
+// stateTracker keeps the state of the PVs waiting for their deletion, and the reason of the skipped PVs.
+// It is shared by all the workers.
+type stateTracker struct {
+	mu      sync.Mutex
+	states  map[string]pvState
+	skipped map[string]Reason
+}
+
+// newStateTracker returns an empty stateTracker
+func newStateTracker() *stateTracker {
+	return &stateTracker{states: map[string]pvState{}, skipped: map[string]Reason{}}
+}
+
+// get returns the state of a PV
+func (t *stateTracker) get(name string) (pvState, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.states[name]
+	return state, ok
+}
+
+// since returns when the PV was first seen orphaned or released
+func (t *stateTracker) since(name string) (time.Time, bool) {
+	state, ok := t.get(name)
+	return state.since, ok
+}
+
+// set records the state of a PV, keeping the time it was first seen if the new state has none
+func (t *stateTracker) set(name string, state pvState, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if state.since.IsZero() {
+		state.since = now
+		if prev, ok := t.states[name]; ok && !prev.since.IsZero() {
+			state.since = prev.since
+		}
+	}
+	t.states[name] = state
+	delete(t.skipped, name)
+}
+
+// skip records that a PV is skipped for the given reason and drops its state,
+// it returns whether the PV was not skipped for that reason already
+func (t *stateTracker) skip(name string, reason Reason) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.states, name)
+	prev, ok := t.skipped[name]
+	t.skipped[name] = reason
+	return !ok || prev != reason
+}
+
+// forget drops the state of a PV that got deleted or does not wait for its deletion anymore
+func (t *stateTracker) forget(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.states, name)
+	delete(t.skipped, name)
+}
+
+// oldestFirst returns the names of the PVs tracked with the given reason, sorted by the time they were first seen
+func (t *stateTracker) oldestFirst(reason string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var names []string
+	for name, state := range t.states {
+		if state.reason == reason {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := t.states[names[i]].since, t.states[names[j]].since
+		return a.Before(b) || (a.Equal(b) && names[i] < names[j])
+	})
+	return names
+}
+
+// len returns the number of tracked PVs
+func (t *stateTracker) len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.states)
+}
+
+// stateKey identifies a series of the gauges computed from the tracked states
+type stateKey struct {
+	storageClass string
+	reason       string
+}
+
+// stateCollector reports the PVs waiting for their deletion, computed from the tracker on every scrape
+type stateCollector struct {
+	tracker *stateTracker
+
+	orphaned *prometheus.Desc
+	grace    *prometheus.Desc
+	capacity *prometheus.Desc
+}
+
+// newStateCollector returns a stateCollector reading from the given tracker
+func newStateCollector(tracker *stateTracker) *stateCollector {
+	return &stateCollector{
+		tracker: tracker,
+		orphaned: prometheus.NewDesc("local_pv_cleaner_orphaned_pvs",
+			"Number of Orphaned PVs that are not deleted yet",
+			[]string{"storage_class", "reason"}, nil),
+		grace: prometheus.NewDesc("local_pv_cleaner_grace_period_pvs",
+			"Number of Released PVs waiting out the released TTL before their deletion",
+			[]string{"storage_class", "reason"}, nil),
+		capacity: prometheus.NewDesc("local_pv_cleaner_orphaned_capacity_bytes",
+			"Total capacity of the Orphaned PVs that are not deleted yet",
+			[]string{"storage_class"}, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *stateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.orphaned
+	ch <- c.grace
+	ch <- c.capacity
+}
+
+// Collect implements prometheus.Collector
+func (c *stateCollector) Collect(ch chan<- prometheus.Metric) {
+	orphaned := map[stateKey]int{}
+	grace := map[stateKey]int{}
+	capacity := map[string]int64{}
+
+	c.tracker.mu.Lock()
+	for _, state := range c.tracker.states {
+		key := stateKey{storageClass: state.storageClass, reason: state.reason}
+		if state.grace {
+			grace[key]++
+		} else if state.orphan {
+			orphaned[key]++
+		}
+		if state.orphan {
+			capacity[state.storageClass] += state.capacity
+		}
+	}
+	c.tracker.mu.Unlock()
+
+	for key, count := range orphaned {
+		ch <- prometheus.MustNewConstMetric(c.orphaned, prometheus.GaugeValue, float64(count),
+			key.storageClass, key.reason)
+	}
+	for key, count := range grace {
+		ch <- prometheus.MustNewConstMetric(c.grace, prometheus.GaugeValue, float64(count), key.storageClass, key.reason)
+	}
+	for storageClass, bytes := range capacity {
+		ch <- prometheus.MustNewConstMetric(c.capacity, prometheus.GaugeValue, float64(bytes), storageClass)
+	}
+}
+
+// tracker returns the state tracker of the controller
+func (r *PVCleanupController) tracker() *stateTracker {
+	r.statesOnce.Do(func() {
+		r.states = newStateTracker()
+	})
+	return r.states
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestStateTracker(t *testing.T) {
+	newPV := func(name, size string) corev1.PersistentVolume {
+		pv := newLocalPV(name, "topolvm", "node-gone")
+		pv.Spec.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)}
+		return *pv
+	}
+	first := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tracker := newStateTracker()
+
+	tracker.set("pv-1", newPVState(newPV("pv-1", "1Gi"), stateReasonOrphanRule, true, false), first)
+	tracker.set("pv-2", newPVState(newPV("pv-2", "2Gi"), guardRetentionHold, true, false), first)
+	tracker.set("pv-3", newPVState(newPV("pv-3", "4Gi"), stateReasonDryRun, true, false), first)
+	tracker.set("pv-4", newPVState(newPV("pv-4", "8Gi"), stateReasonReleasedTTL, false, true), first)
+
+	assert.NoError(t, testutil.CollectAndCompare(newStateCollector(tracker), strings.NewReader(`
+# HELP local_pv_cleaner_grace_period_pvs Number of Released PVs waiting out the released TTL before their deletion
+# TYPE local_pv_cleaner_grace_period_pvs gauge
+local_pv_cleaner_grace_period_pvs{reason="released-ttl",storage_class="topolvm"} 1
+# HELP local_pv_cleaner_orphaned_capacity_bytes Total capacity of the Orphaned PVs that are not deleted yet
+# TYPE local_pv_cleaner_orphaned_capacity_bytes gauge
+local_pv_cleaner_orphaned_capacity_bytes{storage_class="topolvm"} 7.516192768e+09
+# HELP local_pv_cleaner_orphaned_pvs Number of Orphaned PVs that are not deleted yet
+# TYPE local_pv_cleaner_orphaned_pvs gauge
+local_pv_cleaner_orphaned_pvs{reason="retention-hold",storage_class="topolvm"} 1
+local_pv_cleaner_orphaned_pvs{reason="dry-run",storage_class="topolvm"} 1
+local_pv_cleaner_orphaned_pvs{reason="orphan-rule",storage_class="topolvm"} 1
+`)), "Expected only the orphaned PVs to count towards the capacity")
+
+	// the deletion failed, the PV keeps its first seen time
+	tracker.set("pv-1", newPVState(newPV("pv-1", "1Gi"), stateReasonDeleteFailed, true, false), first.Add(time.Hour))
+	since, ok := tracker.since("pv-1")
+	assert.True(t, ok)
+	assert.Equal(t, first, since)
+	assert.Equal(t, 3, testutil.CollectAndCount(newStateCollector(tracker), "local_pv_cleaner_orphaned_pvs"),
+		"Expected the orphan rule series to be replaced")
+
+	tracker.forget("pv-1")
+	tracker.forget("pv-2")
+	tracker.forget("pv-unknown")
+	assert.Equal(t, 2, tracker.len())
+	assert.NoError(t, testutil.CollectAndCompare(newStateCollector(tracker), strings.NewReader(`
+# HELP local_pv_cleaner_orphaned_capacity_bytes Total capacity of the Orphaned PVs that are not deleted yet
+# TYPE local_pv_cleaner_orphaned_capacity_bytes gauge
+local_pv_cleaner_orphaned_capacity_bytes{storage_class="topolvm"} 4.294967296e+09
+`), "local_pv_cleaner_orphaned_capacity_bytes"))
+}
+
+func TestStateTracker_skip(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tracker := newStateTracker()
+	tracker.set("pv-1", newPVState(corev1.PersistentVolume{}, guardRetentionHold, true, false), now)
+
+	assert.True(t, tracker.skip("pv-1", ReasonPVAnnotation))
+	assert.Zero(t, tracker.len(), "Expected a skipped PV to drop its state")
+	assert.False(t, tracker.skip("pv-1", ReasonPVAnnotation), "Expected a PV to be skipped once per reason")
+	assert.True(t, tracker.skip("pv-1", ReasonStorageClass))
+
+	// a PV managed again, or deleted, is reported again once skipped
+	tracker.set("pv-1", newPVState(corev1.PersistentVolume{}, guardRetentionHold, true, false), now)
+	assert.True(t, tracker.skip("pv-1", ReasonStorageClass))
+	tracker.forget("pv-1")
+	assert.True(t, tracker.skip("pv-1", ReasonStorageClass))
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// sweeper periodically compares every PV against the set of existing nodes and enqueues the orphan candidates.
+// It replaces the per-PV requeue timers of PVs whose node exists.
+type sweeper struct {
+	controller *PVCleanupController
+	interval   time.Duration
+	jitter     float64
+	events     chan<- event.GenericEvent
+}
+
+// Start runs the sweeps until the context is cancelled
+func (s *sweeper) Start(ctx context.Context) error {
+	wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+		if err := s.sweep(ctx); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to sweep PVs")
+		}
+	}, s.interval, s.jitter, true)
+
+	return nil
+}
+
+// NeedLeaderElection makes sure only the leader enqueues candidates
+func (s *sweeper) NeedLeaderElection() bool {
+	return true
+}
+
+// sweep lists every PV and Node from the cache and enqueues the orphan candidates
+func (s *sweeper) sweep(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	start := time.Now()
+	defer func() {
+		sweepDurationSeconds.Observe(time.Since(start).Seconds())
+	}()
+
+	var pvList corev1.PersistentVolumeList
+	if err := s.controller.Client.List(ctx, &pvList); err != nil {
+		return err
+	}
+	nodeList := newMetadataList(nodeGVK)
+	if err := s.controller.Client.List(ctx, nodeList); err != nil {
+		return err
+	}
+
+	nodeNames := sets.New[string]()
+	for _, node := range nodeList.Items {
+		nodeNames.Insert(node.Name)
+	}
+
+	candidates := s.controller.orphanCandidates(pvList.Items, nodeNames)
+	s.controller.sortOldestOrphanFirst(candidates)
+	sweepCandidates.Set(float64(len(candidates)))
+	logger.V(1).Info("Swept PVs", "pvs", len(pvList.Items), "nodes", nodeNames.Len(), "candidates", len(candidates))
+
+	for i := range candidates {
+		select {
+		case s.events <- event.GenericEvent{Object: &candidates[i]}:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// orphanCandidates returns the managed PVs whose node is not in the given set of node names.
+// Only the checks that need no API calls are done here, Reconcile makes the final decision.
+func (r *PVCleanupController) orphanCandidates(pvs []corev1.PersistentVolume,
+	nodeNames sets.Set[string]) []corev1.PersistentVolume {
+	p := r.policy()
+	var candidates []corev1.PersistentVolume
+	for _, pv := range pvs {
+		if p.filter(pv) != "" || isTrue(pv.Annotations, SkipAnnotation) {
+			continue
+		}
+
+		nodeName := p.node(pv)
+		if nodeName == "" || nodeNames.Has(nodeName) {
+			continue
+		}
+		candidates = append(candidates, pv)
+	}
+
+	return candidates
+}
+
+// nodeRequeueAfter is the requeue delay of PVs whose node exists, the sweeper takes over when enabled
+func (r *PVCleanupController) nodeRequeueAfter() time.Duration {
+	if r.SweepInterval > 0 {
+		return 0
+	}
+	return r.RequeueDuration
+}
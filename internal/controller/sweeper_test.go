@@ -0,0 +1,112 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+const testNodeSelectorKey = "topology.topolvm.io/node"
+
+func newLocalPV(name, storageClass, nodeName string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			StorageClassName:              storageClass,
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+			NodeAffinity: &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{Key: testNodeSelectorKey, Values: []string{nodeName}}},
+				}},
+			}},
+		},
+		Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+	}
+}
+
+func TestPVCleanupController_orphanCandidates(t *testing.T) {
+	skipped := newLocalPV("pv-skipped", "topolvm", "node-gone")
+	skipped.Annotations = map[string]string{SkipAnnotation: "true"}
+	deletePolicy := newLocalPV("pv-delete-policy", "topolvm", "node-gone")
+	deletePolicy.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimDelete
+
+	pvs := []corev1.PersistentVolume{
+		*newLocalPV("pv-orphan", "topolvm", "node-gone"),
+		*newLocalPV("pv-healthy", "topolvm", "node-01"),
+		*newLocalPV("pv-other-class", "openebs", "node-gone"),
+		*skipped,
+		*deletePolicy,
+		{ObjectMeta: metav1.ObjectMeta{Name: "pv-no-affinity"}, Spec: corev1.PersistentVolumeSpec{
+			StorageClassName:              "topolvm",
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+			NodeAffinity:                  &corev1.VolumeNodeAffinity{},
+		}},
+	}
+
+	r := &PVCleanupController{
+		NodeSelectorKeys:  []string{testNodeSelectorKey},
+		StorageClassNames: []string{"topolvm"},
+	}
+
+	candidates := r.orphanCandidates(pvs, sets.New("node-01"))
+	require.Len(t, candidates, 1)
+	assert.Equal(t, "pv-orphan", candidates[0].Name)
+}
+
+func TestSweeper_sweep(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+
+	ctx := context.Background()
+	fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-01"}},
+		newLocalPV("pv-1", "topolvm", "node-01"),
+		newLocalPV("pv-2", "topolvm", "node-02"),
+		newLocalPV("pv-3", "topolvm", "node-03"),
+	).Build()
+
+	events := make(chan event.GenericEvent, 10)
+	sw := &sweeper{
+		controller: &PVCleanupController{
+			Client:           fakeClient,
+			NodeSelectorKeys: []string{testNodeSelectorKey},
+			SweepInterval:    time.Minute,
+		},
+		events: events,
+	}
+
+	require.NoError(t, sw.sweep(ctx))
+	close(events)
+
+	var enqueued []string
+	for evt := range events {
+		enqueued = append(enqueued, evt.Object.GetName())
+	}
+	assert.ElementsMatch(t, []string{"pv-2", "pv-3"}, enqueued)
+	assert.Zero(t, sw.controller.nodeRequeueAfter(), "Expected no per-PV requeue while the sweeper is enabled")
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// tracerName is the instrumentation scope of the controller spans
+const tracerName = "github.com/kavinraja-g/local-pv-cleaner/internal/controller"
+
+// Attributes of the controller spans
+const (
+	attrPV           = attribute.Key("pv.name")
+	attrStorageClass = attribute.Key("pv.storage_class")
+	attrNode         = attribute.Key("node.name")
+	attrDecision     = attribute.Key("decision")
+	attrGuard        = attribute.Key("guard")
+)
+
+// startSpan starts a child span of the span in the context, using the global tracer provider when no Tracer is set
+func (r *PVCleanupController) startSpan(ctx context.Context, name string,
+	attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	tracer := r.Tracer
+	if tracer == nil {
+		tracer = otel.Tracer(tracerName)
+	}
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// pvAttributes returns the span attributes describing the PV
+func pvAttributes(pv corev1.PersistentVolume) []attribute.KeyValue {
+	return []attribute.KeyValue{attrPV.String(pv.Name), attrStorageClass.String(pv.Spec.StorageClassName)}
+}
+
+// endSpan records the error, if any, and ends the span
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// spanAttributes returns the attributes of a recorded span as a map
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestPVCleanupController_tracing(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+	_ = storagev1.AddToScheme(s)
+
+	var tests = []struct {
+		name         string
+		pv           *corev1.PersistentVolume
+		wantSpans    []string
+		wantDecision reconcileOutcome
+	}{
+		{
+			name:         "Orphaned PV",
+			pv:           newLocalPV("pv-orphan", "topolvm", "node-gone"),
+			wantSpans:    []string{"Reconcile", "resolveNode", "getNode", "deleteOrphanedPV", "checkGuards"},
+			wantDecision: outcomeDelete,
+		},
+		{
+			name:         "PV on a healthy node",
+			pv:           newLocalPV("pv-healthy", "topolvm", "node-01"),
+			wantSpans:    []string{"Reconcile", "resolveNode", "getNode"},
+			wantDecision: outcomeRequeue,
+		},
+		{
+			name:         "PV of an unmanaged storage class",
+			pv:           newLocalPV("pv-other-class", "openebs", "node-gone"),
+			wantSpans:    []string{"Reconcile"},
+			wantDecision: outcomeSkip,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			fakeClient := crFake.NewClientBuilder().WithScheme(s).
+				WithObjects(tt.pv, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-01"}}).
+				WithIndex(&storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV).
+				Build()
+			r := &PVCleanupController{
+				Client:            fakeClient,
+				NodeSelectorKeys:  []string{testNodeSelectorKey},
+				StorageClassNames: []string{"topolvm"},
+				RequeueDuration:   time.Minute,
+				Tracer:            provider.Tracer(tracerName),
+			}
+
+			_, err := r.Reconcile(context.Background(),
+				reconcile.Request{NamespacedName: types.NamespacedName{Name: tt.pv.Name}})
+			require.NoError(t, err)
+
+			spans := exporter.GetSpans()
+			byName := map[string]tracetest.SpanStub{}
+			var names []string
+			for _, span := range spans {
+				byName[span.Name] = span
+				names = append(names, span.Name)
+			}
+			assert.ElementsMatch(t, tt.wantSpans, names)
+
+			root := byName["Reconcile"]
+			assert.False(t, root.Parent.IsValid(), "Expected Reconcile to be the root span")
+			attrs := spanAttributes(root)
+			assert.Equal(t, tt.pv.Name, attrs[attrPV].AsString())
+			assert.Equal(t, tt.pv.Spec.StorageClassName, attrs[attrStorageClass].AsString())
+			assert.Equal(t, string(tt.wantDecision), attrs[attrDecision].AsString())
+
+			for _, span := range spans {
+				switch span.Name {
+				case "Reconcile":
+				case "checkGuards":
+					assert.Equal(t, byName["deleteOrphanedPV"].SpanContext.SpanID(), span.Parent.SpanID())
+				default:
+					assert.Equal(t, root.SpanContext.SpanID(), span.Parent.SpanID(),
+						"Expected %s to be a child of Reconcile", span.Name)
+				}
+			}
+			if lookup, ok := byName["getNode"]; ok {
+				assert.Equal(t, tt.pv.Spec.NodeAffinity.Required.NodeSelectorTerms[0].MatchExpressions[0].Values[0],
+					spanAttributes(lookup)[attrNode].AsString())
+			}
+
+			var pv corev1.PersistentVolume
+			err = fakeClient.Get(context.Background(), client.ObjectKey{Name: tt.pv.Name}, &pv)
+			assert.Equal(t, tt.wantDecision == outcomeDelete, err != nil)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// volumeAttachmentPVIndex is the field index used to look up VolumeAttachments by the PV they reference
+const volumeAttachmentPVIndex = "spec.source.persistentVolumeName"
+
+// indexVolumeAttachmentByPV extracts the PV name referenced by a VolumeAttachment
+func indexVolumeAttachmentByPV(obj client.Object) []string {
+	va, ok := obj.(*storagev1.VolumeAttachment)
+	if !ok || va.Spec.Source.PersistentVolumeName == nil {
+		return nil
+	}
+
+	return []string{*va.Spec.Source.PersistentVolumeName}
+}
+
+// cleanupVolumeAttachments deletes the VolumeAttachments of the given PV whose node no longer exists
+func (r *PVCleanupController) cleanupVolumeAttachments(ctx context.Context, pv corev1.PersistentVolume) error {
+	logger := log.FromContext(ctx)
+
+	var vaList storagev1.VolumeAttachmentList
+	if err := r.Client.List(ctx, &vaList, client.MatchingFields{volumeAttachmentPVIndex: pv.Name}); err != nil {
+		logger.Error(err, "Failed to list VolumeAttachments", "pv", pv.Name)
+		return err
+	}
+
+	for i := range vaList.Items {
+		va := &vaList.Items[i]
+
+		err := r.Client.Get(ctx, client.ObjectKey{Name: va.Spec.NodeName}, newMetadata(nodeGVK))
+		if err == nil {
+			logger.V(1).Info("Node of VolumeAttachment exists, keeping it", "volumeAttachment", va.Name, "node", va.Spec.NodeName)
+			continue
+		}
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		if r.DryRun {
+			logger.Info("DryRun enabled, skipping deletion of VolumeAttachment", "volumeAttachment", va.Name, "pv", pv.Name)
+			continue
+		}
+
+		if err := r.Budget.Wait(ctx, "delete-volume-attachment"); err != nil {
+			return err
+		}
+		if err := r.Client.Delete(ctx, va); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "Failed to delete VolumeAttachment", "volumeAttachment", va.Name, "pv", pv.Name)
+			return err
+		}
+		logger.Info("Deleted stale VolumeAttachment", "volumeAttachment", va.Name, "pv", pv.Name, "node", va.Spec.NodeName)
+		deletedVolumeAttachmentsTotal.WithLabelValues(pv.Spec.StorageClassName).Inc()
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kavinraja-g/local-pv-cleaner/api/v1alpha1"
+	"github.com/kavinraja-g/local-pv-cleaner/internal/window"
+)
+
+func newVolumeAttachment(name, pvName, nodeName string) *storagev1.VolumeAttachment {
+	return &storagev1.VolumeAttachment{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: storagev1.VolumeAttachmentSpec{
+			Attacher: "topolvm.io",
+			NodeName: nodeName,
+			Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: ptr.To(pvName)},
+		},
+	}
+}
+
+func TestPVCleanupController_cleanupVolumeAttachments(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+	_ = storagev1.AddToScheme(s)
+
+	pv := corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv-1"}}
+
+	var tests = []struct {
+		name        string
+		objects     []client.Object
+		dryRun      bool
+		wantDeleted []string
+		wantKept    []string
+	}{
+		{
+			name: "Delete attachments on missing nodes",
+			objects: []client.Object{
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-02"}},
+				newVolumeAttachment("va-1", "pv-1", "node-01"),
+				newVolumeAttachment("va-2", "pv-1", "node-02"),
+				newVolumeAttachment("va-3", "pv-2", "node-01"),
+			},
+			wantDeleted: []string{"va-1"},
+			wantKept:    []string{"va-2", "va-3"},
+		},
+		{
+			name: "DryRun keeps attachments",
+			objects: []client.Object{
+				newVolumeAttachment("va-1", "pv-1", "node-01"),
+			},
+			dryRun:   true,
+			wantKept: []string{"va-1"},
+		},
+		{
+			name:    "No attachments",
+			objects: []client.Object{},
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		fakeClient := crFake.NewClientBuilder().WithScheme(s).WithObjects(tt.objects...).
+			WithIndex(&storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV).
+			Build()
+
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PVCleanupController{
+				Client: fakeClient,
+				DryRun: tt.dryRun,
+			}
+
+			require.NoError(t, r.cleanupVolumeAttachments(ctx, pv))
+
+			for _, name := range tt.wantDeleted {
+				err := fakeClient.Get(ctx, client.ObjectKey{Name: name}, &storagev1.VolumeAttachment{})
+				assert.Error(t, err, "Expected VolumeAttachment %s to be deleted", name)
+			}
+			for _, name := range tt.wantKept {
+				err := fakeClient.Get(ctx, client.ObjectKey{Name: name}, &storagev1.VolumeAttachment{})
+				assert.NoError(t, err, "Expected VolumeAttachment %s to be kept", name)
+			}
+		})
+	}
+}
+
+func TestPVCleanupController_Reconcile_guardsKeepVolumeAttachments(t *testing.T) {
+	s := scheme.Scheme
+	_ = corev1.AddToScheme(s)
+	_ = storagev1.AddToScheme(s)
+	_ = v1alpha1.AddToScheme(s)
+
+	closed, err := window.ParseSchedule([]string{"Mon-Fri 09:00-17:00"})
+	require.NoError(t, err)
+	saturday := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		objects    []client.Object
+		controller func(r *PVCleanupController)
+		wantGuard  string
+	}{
+		{
+			name: "Rejected OrphanedVolume",
+			objects: []client.Object{&v1alpha1.OrphanedVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+				Spec:       v1alpha1.OrphanedVolumeSpec{PersistentVolumeName: "pv-1", Approval: v1alpha1.Rejected},
+			}},
+			controller: func(r *PVCleanupController) {
+				r.RequireApproval = true
+			},
+			wantGuard: guardApproval,
+		},
+		{
+			name: "Closed maintenance window",
+			controller: func(r *PVCleanupController) {
+				r.MaintenanceWindows = closed
+			},
+			wantGuard: guardMaintenanceWindow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			pv := newLocalPV("pv-1", "topolvm", "node-gone")
+			va := newVolumeAttachment("va-1", pv.Name, "node-gone")
+			fakeClient := crFake.NewClientBuilder().WithScheme(s).
+				WithObjects(append(tt.objects, pv, va)...).
+				WithIndex(&storagev1.VolumeAttachment{}, volumeAttachmentPVIndex, indexVolumeAttachmentByPV).
+				WithStatusSubresource(&v1alpha1.OrphanedVolume{}).Build()
+			r := &PVCleanupController{
+				Client:            fakeClient,
+				NodeSelectorKeys:  []string{testNodeSelectorKey},
+				StorageClassNames: []string{"topolvm"},
+				RequeueDuration:   15 * time.Minute,
+				Clock:             clocktesting.NewFakePassiveClock(saturday),
+			}
+			tt.controller(r)
+
+			// a first reconcile detects the PV, the next one sees the review
+			for range 2 {
+				_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: pv.Name}})
+				require.NoError(t, err)
+			}
+			assert.Equal(t, []string{pv.Name}, r.tracker().oldestFirst(tt.wantGuard))
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(pv), &corev1.PersistentVolume{}))
+			assert.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(va), &storagev1.VolumeAttachment{}),
+				"Expected the VolumeAttachment to be kept while a guard holds the PV")
+		})
+	}
+}
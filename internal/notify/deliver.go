@@ -0,0 +1,157 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader holds the hex encoded HMAC-SHA256 of the timestamp, a dot and the body
+	SignatureHeader = "X-Local-PV-Cleaner-Signature"
+	// TimestampHeader holds the unix time the payload was signed at
+	TimestampHeader = "X-Local-PV-Cleaner-Timestamp"
+	// source identifies the controller in the JSON payloads
+	source = "local-pv-cleaner"
+)
+
+// jsonPayload is the body of the json format
+type jsonPayload struct {
+	Source string  `json:"source"`
+	Count  int     `json:"count"`
+	Events []Event `json:"events"`
+}
+
+// slackPayload is the body of the slack format, accepted by Slack and compatible incoming webhooks
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+// slackEmoji decorates the kinds of events in the slack format
+var slackEmoji = map[Kind]string{
+	KindOrphanFound:  ":warning:",
+	KindDeleted:      ":wastebasket:",
+	KindDryRun:       ":test_tube:",
+	KindDeleteFailed: ":x:",
+}
+
+// render returns the body of a batch in the given format
+func render(format Format, batch []Event) ([]byte, error) {
+	if format == FormatSlack {
+		var text strings.Builder
+		fmt.Fprintf(&text, "*local-pv-cleaner*: %d event(s)", len(batch))
+		for _, event := range batch {
+			fmt.Fprintf(&text, "\n%s `%s` PV `%s` (%s)", slackEmoji[event.Kind], event.Kind, event.PV, event.StorageClass)
+			if event.Node != "" {
+				fmt.Fprintf(&text, " on node `%s`", event.Node)
+			}
+			if event.Claim != "" {
+				fmt.Fprintf(&text, " claimed by `%s`", event.Claim)
+			}
+			if event.Message != "" {
+				fmt.Fprintf(&text, ": %s", event.Message)
+			}
+		}
+		return json.Marshal(slackPayload{Text: text.String()})
+	}
+
+	return json.Marshal(jsonPayload{Source: source, Count: len(batch), Events: batch})
+}
+
+// Sign returns the signature of a body signed at the given unix time
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryable reports whether a response status is worth a retry
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// deliver posts a batch to a webhook, retrying the transient failures
+func (n *Notifier) deliver(ctx context.Context, webhook Webhook, batch []Event) error {
+	body, err := render(webhook.Format, batch)
+	if err != nil {
+		return err
+	}
+
+	delay := n.opts.RetryBaseDelay
+	for attempt := 0; ; attempt++ {
+		if err := n.limiter.Wait(ctx); err != nil {
+			return err
+		}
+		retry, err := n.post(ctx, webhook.URL, body)
+		if err == nil || !retry || attempt >= n.opts.MaxRetries {
+			return err
+		}
+
+		select {
+		case <-time.After(delay):
+			delay *= 2
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// post sends a single request and reports whether a failure is worth a retry
+func (n *Notifier) post(ctx context.Context, url string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(n.opts.SigningSecret) > 0 {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(n.opts.SigningSecret, timestamp, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// unwrapped, the error would log the webhook URL and its token
+		var urlErr *neturl.Error
+		if errors.As(err, &urlErr) {
+			err = fmt.Errorf("notification webhook %s %s: %w", urlErr.Op, req.URL.Host, urlErr.Err)
+		}
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return retryable(resp.StatusCode), fmt.Errorf("notification webhook returned %s", resp.Status)
+	}
+	return false, nil
+}
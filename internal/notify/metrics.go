@@ -0,0 +1,41 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Results of the notifications, used as the result label of the notifications metric
+const (
+	resultSent    = "sent"
+	resultFailed  = "failed"
+	resultDropped = "dropped"
+)
+
+var notificationsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "local_pv_cleaner_notifications_total",
+		Help: "Total number of notification messages by result, dropped counts events lost to a full buffer",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(notificationsTotal)
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notify sends batched notifications about the cleanup activity to HTTP webhooks
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Kind is the kind of a notified event
+type Kind string
+
+const (
+	// KindOrphanFound is sent when the node of a PV is found missing
+	KindOrphanFound Kind = "orphan-found"
+	// KindDeleted is sent when a PV got deleted
+	KindDeleted Kind = "deleted"
+	// KindDryRun is sent when a PV would have been deleted without the dry-run mode
+	KindDryRun Kind = "dry-run"
+	// KindDeleteFailed is sent when the deletion of a PV failed
+	KindDeleteFailed Kind = "delete-failed"
+)
+
+// Event is a single cleanup activity
+type Event struct {
+	Time         time.Time `json:"time"`
+	Kind         Kind      `json:"kind"`
+	PV           string    `json:"pv"`
+	StorageClass string    `json:"storageClass"`
+	Node         string    `json:"node,omitempty"`
+	Claim        string    `json:"claim,omitempty"`
+	Message      string    `json:"message,omitempty"`
+}
+
+// Format is the payload template of a webhook
+type Format string
+
+const (
+	// FormatJSON posts the batch as a plain JSON document
+	FormatJSON Format = "json"
+	// FormatSlack posts the batch as a Slack-compatible text message
+	FormatSlack Format = "slack"
+)
+
+// Webhook is an HTTP endpoint receiving the notifications
+type Webhook struct {
+	URL    string
+	Format Format
+}
+
+// ParseWebhook parses a webhook spec, an URL optionally prefixed with its format such as "slack=https://..."
+func ParseWebhook(spec string) (Webhook, error) {
+	format, url, ok := strings.Cut(spec, "=")
+	if !ok || strings.Contains(format, "/") {
+		format, url = string(FormatJSON), spec
+	}
+
+	switch Format(format) {
+	case FormatJSON, FormatSlack:
+	default:
+		return Webhook{}, fmt.Errorf("unsupported notification format %q, expected json or slack", format)
+	}
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return Webhook{}, fmt.Errorf("invalid notification webhook URL %q", url)
+	}
+
+	return Webhook{URL: url, Format: Format(format)}, nil
+}
+
+// Options configures a Notifier
+type Options struct {
+	// Webhooks receive every batch
+	Webhooks []Webhook
+	// Kinds are the notified kinds of events, every kind is notified when empty
+	Kinds []Kind
+	// BatchInterval is how long events are collected before they are sent
+	BatchInterval time.Duration
+	// MaxBatchSize sends a batch early once it holds that many events
+	MaxBatchSize int
+	// RateLimit is the maximum number of messages per second over all webhooks, unlimited when zero
+	RateLimit float64
+	// MaxRetries is how often a failed message is retried
+	MaxRetries int
+	// RetryBaseDelay is the delay before the first retry, doubled on every further retry
+	RetryBaseDelay time.Duration
+	// SigningSecret signs the payloads when set
+	SigningSecret []byte
+	// Timeout bounds every HTTP request
+	Timeout time.Duration
+	// BufferSize is the number of events buffered before new ones are dropped
+	BufferSize int
+}
+
+// Notifier collects events and sends them in batches to the webhooks.
+// It implements the manager Runnable interface.
+type Notifier struct {
+	opts    Options
+	kinds   map[Kind]bool
+	events  chan Event
+	limiter *rate.Limiter
+	client  *http.Client
+}
+
+// New returns a Notifier, nil when no webhook is configured
+func New(opts Options) *Notifier {
+	if len(opts.Webhooks) == 0 {
+		return nil
+	}
+	if opts.BatchInterval <= 0 {
+		opts.BatchInterval = 30 * time.Second
+	}
+	if opts.MaxBatchSize <= 0 {
+		opts.MaxBatchSize = 50
+	}
+	if opts.RetryBaseDelay <= 0 {
+		opts.RetryBaseDelay = time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 1000
+	}
+
+	limiter := rate.NewLimiter(rate.Inf, 0)
+	if opts.RateLimit > 0 {
+		limiter = rate.NewLimiter(rate.Limit(opts.RateLimit), 1)
+	}
+	var kinds map[Kind]bool
+	if len(opts.Kinds) > 0 {
+		kinds = map[Kind]bool{}
+		for _, kind := range opts.Kinds {
+			kinds[kind] = true
+		}
+	}
+
+	return &Notifier{
+		opts:    opts,
+		kinds:   kinds,
+		events:  make(chan Event, opts.BufferSize),
+		limiter: limiter,
+		client:  &http.Client{Timeout: opts.Timeout},
+	}
+}
+
+// Notify queues an event without blocking, the event is dropped when the buffer is full
+func (n *Notifier) Notify(event Event) {
+	if n == nil || (n.kinds != nil && !n.kinds[event.Kind]) {
+		return
+	}
+
+	select {
+	case n.events <- event:
+	default:
+		notificationsTotal.WithLabelValues(resultDropped).Inc()
+	}
+}
+
+// NeedLeaderElection makes sure only the leader, which does the cleanup, sends notifications
+func (n *Notifier) NeedLeaderElection() bool {
+	return true
+}
+
+// Start sends the batches until the context is cancelled, and then flushes the pending events
+func (n *Notifier) Start(ctx context.Context) error {
+	ticker := time.NewTicker(n.opts.BatchInterval)
+	defer ticker.Stop()
+
+	var batch []Event
+	for {
+		select {
+		case event := <-n.events:
+			batch = append(batch, event)
+			if len(batch) >= n.opts.MaxBatchSize {
+				n.send(ctx, batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				n.send(ctx, batch)
+				batch = nil
+			}
+		case <-ctx.Done():
+			n.flush(batch)
+			return nil
+		}
+	}
+}
+
+// flush sends the pending events once the notifier is stopped
+func (n *Notifier) flush(batch []Event) {
+	for drained := false; !drained; {
+		select {
+		case event := <-n.events:
+			batch = append(batch, event)
+		default:
+			drained = true
+		}
+	}
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.opts.Timeout)
+	defer cancel()
+	n.send(ctx, batch)
+}
+
+// send delivers a batch to every webhook
+func (n *Notifier) send(ctx context.Context, batch []Event) {
+	for _, webhook := range n.opts.Webhooks {
+		if err := n.deliver(ctx, webhook, batch); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to send notification", "events", len(batch))
+			notificationsTotal.WithLabelValues(resultFailed).Inc()
+			continue
+		}
+		notificationsTotal.WithLabelValues(resultSent).Inc()
+	}
+}
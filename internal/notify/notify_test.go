@@ -0,0 +1,216 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// standIn is a local HTTP stand-in of a webhook, answering with the queued statuses and then 200
+type standIn struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+	server   *httptest.Server
+}
+
+func newStandIn(t *testing.T, statuses ...int) *standIn {
+	s := &standIn{statuses: statuses}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests = append(s.requests, req)
+		s.bodies = append(s.bodies, body)
+		status := http.StatusOK
+		if len(s.statuses) > 0 {
+			status, s.statuses = s.statuses[0], s.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *standIn) received() ([]*http.Request, [][]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*http.Request(nil), s.requests...), append([][]byte(nil), s.bodies...)
+}
+
+func newEvent(kind Kind, pv string) Event {
+	return Event{
+		Time: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC), Kind: kind, PV: pv, StorageClass: "topolvm",
+		Node: "node-gone", Claim: "team-a/data",
+	}
+}
+
+// run starts the notifier and returns a function stopping it and waiting for the flush
+func run(n *Notifier) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = n.Start(ctx)
+		close(done)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func TestParseWebhook(t *testing.T) {
+	var tests = []struct {
+		spec    string
+		want    Webhook
+		wantErr bool
+	}{
+		{spec: "https://example.com/hook", want: Webhook{URL: "https://example.com/hook", Format: FormatJSON}},
+		{spec: "slack=https://hooks.slack.com/services/x", want: Webhook{
+			URL: "https://hooks.slack.com/services/x", Format: FormatSlack}},
+		{spec: "https://example.com/hook?token=a=b", want: Webhook{
+			URL: "https://example.com/hook?token=a=b", Format: FormatJSON}},
+		{spec: "teams=https://example.com/hook", wantErr: true},
+		{spec: "json=example.com/hook", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseWebhook(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNotifier_batching(t *testing.T) {
+	stub := newStandIn(t)
+	n := New(Options{
+		Webhooks:      []Webhook{{URL: stub.server.URL, Format: FormatJSON}},
+		Kinds:         []Kind{KindOrphanFound, KindDeleted},
+		BatchInterval: time.Hour,
+		MaxBatchSize:  3,
+	})
+	stop := run(n)
+
+	for _, pv := range []string{"pv-1", "pv-2", "pv-3", "pv-4"} {
+		n.Notify(newEvent(KindDeleted, pv))
+	}
+	n.Notify(newEvent(KindDryRun, "pv-5"))
+	require.Eventually(t, func() bool {
+		requests, _ := stub.received()
+		return len(requests) == 1
+	}, 5*time.Second, 10*time.Millisecond, "Expected a full batch to be sent early")
+
+	stop()
+	_, bodies := stub.received()
+	require.Len(t, bodies, 2, "Expected the pending events to be flushed on shutdown")
+
+	var first, second jsonPayload
+	require.NoError(t, json.Unmarshal(bodies[0], &first))
+	require.NoError(t, json.Unmarshal(bodies[1], &second))
+	assert.Equal(t, "local-pv-cleaner", first.Source)
+	assert.Equal(t, 3, first.Count)
+	assert.Equal(t, []Event{newEvent(KindDeleted, "pv-4")}, second.Events, "Expected the dry-run event to be filtered")
+}
+
+func TestNotifier_slackAndSigning(t *testing.T) {
+	stub := newStandIn(t)
+	secret := []byte("s3cret")
+	n := New(Options{
+		Webhooks:      []Webhook{{URL: stub.server.URL, Format: FormatSlack}},
+		BatchInterval: time.Hour,
+		SigningSecret: secret,
+	})
+	stop := run(n)
+	n.Notify(newEvent(KindOrphanFound, "pv-1"))
+	stop()
+
+	requests, bodies := stub.received()
+	require.Len(t, requests, 1)
+	timestamp := requests[0].Header.Get(TimestampHeader)
+	assert.NotEmpty(t, timestamp)
+	assert.Equal(t, Sign(secret, timestamp, bodies[0]), requests[0].Header.Get(SignatureHeader))
+
+	var payload slackPayload
+	require.NoError(t, json.Unmarshal(bodies[0], &payload))
+	assert.Contains(t, payload.Text, "`orphan-found` PV `pv-1` (topolvm) on node `node-gone` claimed by `team-a/data`")
+}
+
+func TestNotifier_retries(t *testing.T) {
+	var tests = []struct {
+		name         string
+		statuses     []int
+		maxRetries   int
+		wantRequests int
+		wantErr      bool
+	}{
+		{name: "Retried until success", statuses: []int{503, 429}, maxRetries: 3, wantRequests: 3},
+		{name: "Retries exhausted", statuses: []int{500, 500, 500}, maxRetries: 2, wantRequests: 3, wantErr: true},
+		{name: "Client errors are not retried", statuses: []int{400}, maxRetries: 3, wantRequests: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := newStandIn(t, tt.statuses...)
+			n := New(Options{
+				Webhooks:       []Webhook{{URL: stub.server.URL}},
+				MaxRetries:     tt.maxRetries,
+				RetryBaseDelay: time.Millisecond,
+				RateLimit:      1000,
+			})
+
+			err := n.deliver(context.Background(), n.opts.Webhooks[0], []Event{newEvent(KindDeleted, "pv-1")})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			requests, _ := stub.received()
+			assert.Len(t, requests, tt.wantRequests)
+		})
+	}
+}
+
+func TestNotifier_networkErrorHidesTheURL(t *testing.T) {
+	n := New(Options{Webhooks: []Webhook{{URL: "http://127.0.0.1:1/hooks/s3cr3t"}}, RateLimit: 1000})
+	_, err := n.post(context.Background(), n.opts.Webhooks[0].URL, []byte("{}"))
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "s3cr3t")
+	assert.Contains(t, err.Error(), "127.0.0.1:1")
+}
+
+func TestNotifier_disabled(t *testing.T) {
+	n := New(Options{})
+	assert.Nil(t, n)
+	n.Notify(newEvent(KindDeleted, "pv-1"))
+}
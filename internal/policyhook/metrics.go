@@ -0,0 +1,51 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyhook
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Sources of the verdicts, used as the source label of the reviews metric
+const (
+	sourceWebhook       = "webhook"
+	sourceCache         = "cache"
+	sourceFailurePolicy = "failure-policy"
+)
+
+var (
+	reviewsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "local_pv_cleaner_policy_webhook_reviews_total",
+			Help: "Total number of policy webhook reviews by decision and source, the source is the webhook, the cache or the failure policy",
+		},
+		[]string{"decision", "source"},
+	)
+
+	reviewDurationSeconds = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "local_pv_cleaner_policy_webhook_request_duration_seconds",
+			Help:    "Duration of the policy webhook requests",
+			Buckets: prometheus.DefBuckets,
+		},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(reviewsTotal, reviewDurationSeconds)
+}
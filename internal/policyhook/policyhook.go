@@ -0,0 +1,338 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policyhook asks an external policy decision point whether a PV may be deleted
+package policyhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const (
+	// APIVersion is the version of the review requests and responses
+	APIVersion = "localpvcleaner.io/v1alpha1"
+	// Kind is the kind of the review requests and responses
+	Kind = "DeletionReview"
+)
+
+// Decision is the answer of the policy decision point
+type Decision string
+
+const (
+	// Allow lets the deletion go ahead
+	Allow Decision = "allow"
+	// Deny blocks the deletion, the PV is reviewed again after the requeue duration
+	Deny Decision = "deny"
+	// Defer postpones the deletion, the PV is reviewed again after the retry delay of the response
+	Defer Decision = "defer"
+)
+
+// FailurePolicy is what happens when the policy decision point cannot be reached or answers garbage
+type FailurePolicy string
+
+const (
+	// FailOpen allows the deletion
+	FailOpen FailurePolicy = "open"
+	// FailClosed defers the deletion
+	FailClosed FailurePolicy = "closed"
+)
+
+// ParseFailurePolicy converts the given name to a FailurePolicy
+func ParseFailurePolicy(name string) (FailurePolicy, error) {
+	switch FailurePolicy(name) {
+	case FailOpen, FailClosed:
+		return FailurePolicy(name), nil
+	default:
+		return "", fmt.Errorf("unsupported failure policy %q, expected open or closed", name)
+	}
+}
+
+// Node is the evidence about the node of the PV
+type Node struct {
+	Name  string `json:"name"`
+	Found bool   `json:"found"`
+}
+
+// Request is the review of a proposed deletion sent to the policy decision point
+type Request struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// UID identifies the request
+	UID string `json:"uid"`
+	// Action is the proposed action, delete or force-delete
+	Action string `json:"action"`
+	// Cause is why the PV is deleted, orphaned or released-ttl
+	Cause string                        `json:"cause"`
+	PV    *corev1.PersistentVolume      `json:"pv"`
+	PVC   *corev1.PersistentVolumeClaim `json:"pvc,omitempty"`
+	Node  Node                          `json:"node"`
+	// Evidence are the facts behind the decision of the controller
+	Evidence map[string]string `json:"evidence,omitempty"`
+	// Controller is the identity of the controller instance
+	Controller string `json:"controller,omitempty"`
+}
+
+// Response is the answer of the policy decision point
+type Response struct {
+	APIVersion string   `json:"apiVersion,omitempty"`
+	Kind       string   `json:"kind,omitempty"`
+	UID        string   `json:"uid,omitempty"`
+	Decision   Decision `json:"decision"`
+	// Reason explains the decision, it is recorded in the events and the audit log
+	Reason string `json:"reason,omitempty"`
+	// RetryAfterSeconds is when a deferred PV is reviewed again, the requeue duration is used when zero
+	RetryAfterSeconds int `json:"retryAfterSeconds,omitempty"`
+}
+
+// Verdict is the outcome of a review
+type Verdict struct {
+	Decision   Decision
+	Reason     string
+	RetryAfter time.Duration
+	// Err is set when the review failed and the failure policy decided
+	Err error
+}
+
+// Options configures a Client
+type Options struct {
+	// URL is the HTTP endpoint of the policy decision point
+	URL string
+	// Timeout bounds every review
+	Timeout time.Duration
+	// FailurePolicy decides when the review fails, closed by default
+	FailurePolicy FailurePolicy
+	// CAFile holds the CA certificates verifying the endpoint, the system ones are used when empty
+	CAFile string
+	// CertFile and KeyFile hold the client certificate presented to the endpoint for mutual TLS
+	CertFile string
+	KeyFile  string
+	// CacheTTL is how long allow and deny answers are reused for the same PV and evidence, nothing is cached when zero
+	CacheTTL time.Duration
+	// Identity identifies the controller instance in the requests
+	Identity string
+}
+
+// cached is a remembered answer
+type cached struct {
+	verdict Verdict
+	expires time.Time
+}
+
+// Client reviews proposed deletions with the policy decision point
+type Client struct {
+	opts   Options
+	client *http.Client
+	now    func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cached
+}
+
+// New returns a Client, nil when no URL is configured
+func New(opts Options) (*Client, error) {
+	if opts.URL == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(opts.URL, "http://") && !strings.HasPrefix(opts.URL, "https://") {
+		return nil, fmt.Errorf("invalid policy webhook URL %q", opts.URL)
+	}
+	if opts.FailurePolicy == "" {
+		opts.FailurePolicy = FailClosed
+	}
+	if _, err := ParseFailurePolicy(string(opts.FailurePolicy)); err != nil {
+		return nil, err
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	tlsConfig, err := opts.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &Client{
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout, Transport: transport},
+		now:    time.Now,
+		cache:  map[string]cached{},
+	}, nil
+}
+
+// tlsConfig returns the TLS configuration trusting the CA and presenting the client certificate
+func (o Options) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if o.CAFile != "" {
+		pem, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read policy webhook CA: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in policy webhook CA %s", o.CAFile)
+		}
+	}
+	if o.CertFile != "" || o.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load policy webhook client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// Review asks whether the deletion of the request may go ahead, applying the failure policy when the review fails
+func (c *Client) Review(ctx context.Context, req Request) Verdict {
+	key := cacheKey(req)
+	if verdict, ok := c.cached(key); ok {
+		reviewsTotal.WithLabelValues(string(verdict.Decision), sourceCache).Inc()
+		return verdict
+	}
+
+	req.APIVersion, req.Kind = APIVersion, Kind
+	req.UID = string(uuid.NewUUID())
+	req.Controller = c.opts.Identity
+	start := time.Now()
+	resp, err := c.send(ctx, req)
+	reviewDurationSeconds.Observe(time.Since(start).Seconds())
+	if err != nil {
+		verdict := Verdict{Decision: Allow, Err: err, Reason: fmt.Sprintf("Policy webhook failed, failing open: %s", err)}
+		if c.opts.FailurePolicy == FailClosed {
+			verdict.Decision = Defer
+			verdict.Reason = fmt.Sprintf("Policy webhook failed, failing closed: %s", err)
+		}
+		reviewsTotal.WithLabelValues(string(verdict.Decision), sourceFailurePolicy).Inc()
+		return verdict
+	}
+
+	verdict := Verdict{
+		Decision:   resp.Decision,
+		Reason:     resp.Reason,
+		RetryAfter: time.Duration(resp.RetryAfterSeconds) * time.Second,
+	}
+	reviewsTotal.WithLabelValues(string(verdict.Decision), sourceWebhook).Inc()
+	if verdict.Decision != Defer {
+		c.remember(key, verdict)
+	}
+	return verdict
+}
+
+// send posts the request and decodes the response
+func (c *Client) send(ctx context.Context, req Request) (*Response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close() //nolint:errcheck
+	data, err := io.ReadAll(io.LimitReader(httpResp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("policy webhook answered %s", httpResp.Status)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("invalid policy webhook response: %w", err)
+	}
+	switch resp.Decision {
+	case Allow, Deny, Defer:
+	default:
+		return nil, fmt.Errorf("invalid policy webhook decision %q, expected allow, deny or defer", resp.Decision)
+	}
+	if resp.UID != "" && resp.UID != req.UID {
+		return nil, fmt.Errorf("policy webhook answered request %s instead of %s", resp.UID, req.UID)
+	}
+	return &resp, nil
+}
+
+// cacheKey identifies a review by the PV, the proposed action and the evidence, so changed facts are reviewed again.
+// The orphanedSince evidence is left out: it falls back to the current time for the PVs the controller has not
+// tracked yet, which would make every review after a restart miss the cache.
+func cacheKey(req Request) string {
+	stable := maps.Clone(req.Evidence)
+	delete(stable, "orphanedSince")
+	evidence, _ := json.Marshal(stable)
+	var uid string
+	if req.PV != nil {
+		uid = string(req.PV.UID) + "/" + req.PV.Name
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{uid, req.Action, req.Cause, string(evidence)}, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// cached returns a remembered verdict that did not expire
+func (c *Client) cached(key string) (Verdict, bool) {
+	if c.opts.CacheTTL <= 0 {
+		return Verdict{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.cache[key]
+	if !ok || !c.now().Before(entry.expires) {
+		return Verdict{}, false
+	}
+	return entry.verdict, true
+}
+
+// remember caches a verdict and prunes the expired ones
+func (c *Client) remember(key string, verdict Verdict) {
+	if c.opts.CacheTTL <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for k, entry := range c.cache {
+		if !now.Before(entry.expires) {
+			delete(c.cache, k)
+		}
+	}
+	c.cache[key] = cached{verdict: verdict, expires: now.Add(c.opts.CacheTTL)}
+}
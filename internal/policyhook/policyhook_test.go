@@ -0,0 +1,253 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyhook
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newRequest(evidence map[string]string) Request {
+	return Request{
+		Action:   "delete",
+		Cause:    "orphaned",
+		PV:       &corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv-1", UID: "uid-1"}},
+		Node:     Node{Name: "node-gone"},
+		Evidence: evidence,
+	}
+}
+
+// answer returns a handler answering every review with the given response
+func answer(t *testing.T, calls *atomic.Int32, resp Response) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		var req Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, APIVersion, req.APIVersion)
+		assert.Equal(t, Kind, req.Kind)
+		assert.Equal(t, "pv-1", req.PV.Name)
+		resp.UID = req.UID
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func TestClient_Review(t *testing.T) {
+	var tests = []struct {
+		name           string
+		handler        func(t *testing.T, calls *atomic.Int32) http.HandlerFunc
+		failurePolicy  FailurePolicy
+		wantDecision   Decision
+		wantRetryAfter time.Duration
+		wantErr        bool
+	}{
+		{
+			name: "Allow",
+			handler: func(t *testing.T, calls *atomic.Int32) http.HandlerFunc {
+				return answer(t, calls, Response{Decision: Allow})
+			},
+			wantDecision: Allow,
+		},
+		{
+			name: "Deny",
+			handler: func(t *testing.T, calls *atomic.Int32) http.HandlerFunc {
+				return answer(t, calls, Response{Decision: Deny, Reason: "change freeze"})
+			},
+			wantDecision: Deny,
+		},
+		{
+			name: "Defer",
+			handler: func(t *testing.T, calls *atomic.Int32) http.HandlerFunc {
+				return answer(t, calls, Response{Decision: Defer, RetryAfterSeconds: 600})
+			},
+			wantDecision:   Defer,
+			wantRetryAfter: 10 * time.Minute,
+		},
+		{
+			name: "Server error fails closed",
+			handler: func(t *testing.T, calls *atomic.Int32) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusInternalServerError) }
+			},
+			wantDecision: Defer,
+			wantErr:      true,
+		},
+		{
+			name: "Unknown decision fails open",
+			handler: func(t *testing.T, calls *atomic.Int32) http.HandlerFunc {
+				return answer(t, calls, Response{Decision: "maybe"})
+			},
+			failurePolicy: FailOpen,
+			wantDecision:  Allow,
+			wantErr:       true,
+		},
+		{
+			name: "Timeout fails closed",
+			handler: func(t *testing.T, calls *atomic.Int32) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) { time.Sleep(200 * time.Millisecond) }
+			},
+			wantDecision: Defer,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(tt.handler(t, &calls))
+			defer srv.Close()
+
+			c, err := New(Options{URL: srv.URL, Timeout: 50 * time.Millisecond, FailurePolicy: tt.failurePolicy})
+			require.NoError(t, err)
+			verdict := c.Review(context.Background(), newRequest(nil))
+			assert.Equal(t, tt.wantDecision, verdict.Decision)
+			assert.Equal(t, tt.wantRetryAfter, verdict.RetryAfter)
+			assert.Equal(t, tt.wantErr, verdict.Err != nil)
+		})
+	}
+}
+
+func TestClient_Review_cache(t *testing.T) {
+	var calls atomic.Int32
+	decision := Deny
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		answer(t, &calls, Response{Decision: decision})(w, r)
+	}))
+	defer srv.Close()
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	c, err := New(Options{URL: srv.URL, CacheTTL: time.Minute})
+	require.NoError(t, err)
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	assert.Equal(t, Deny, c.Review(ctx, newRequest(map[string]string{"nodeFound": "false"})).Decision)
+	assert.Equal(t, Deny, c.Review(ctx, newRequest(map[string]string{"nodeFound": "false"})).Decision)
+	assert.Equal(t, int32(1), calls.Load(), "Expected the second review to be answered from the cache")
+
+	// the time a PV was first seen orphaned changes after a restart, without the facts changing
+	assert.Equal(t, Deny, c.Review(ctx, newRequest(map[string]string{"nodeFound": "false",
+		"orphanedSince": "2025-03-01T12:00:00Z"})).Decision)
+	assert.Equal(t, Deny, c.Review(ctx, newRequest(map[string]string{"nodeFound": "false",
+		"orphanedSince": "2025-03-01T12:05:00Z"})).Decision)
+	assert.Equal(t, int32(1), calls.Load(), "Expected the orphanedSince evidence to be left out of the cache key")
+
+	// changed evidence is reviewed again
+	decision = Allow
+	assert.Equal(t, Allow, c.Review(ctx, newRequest(map[string]string{"nodeFound": "false", "phase": "Released"})).Decision)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// expired answers are not reused
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, Allow, c.Review(ctx, newRequest(map[string]string{"nodeFound": "false"})).Decision)
+	assert.Equal(t, int32(3), calls.Load())
+
+	// deferrals are never cached
+	decision = Defer
+	other := newRequest(nil)
+	other.Action = "force-delete"
+	c.Review(ctx, other)
+	c.Review(ctx, other)
+	assert.Equal(t, int32(5), calls.Load())
+}
+
+func TestClient_Review_mutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	clientCA, clientCert, clientKey := newClientCertificate(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "client.crt"), clientCert, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "client.key"), clientKey, 0o600))
+
+	var calls atomic.Int32
+	srv := httptest.NewUnstartedServer(answer(t, &calls, Response{Decision: Allow}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCA}
+	srv.StartTLS()
+	defer srv.Close()
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ca.crt"), serverCA, 0o600))
+
+	c, err := New(Options{URL: srv.URL, CAFile: filepath.Join(dir, "ca.crt"),
+		CertFile: filepath.Join(dir, "client.crt"), KeyFile: filepath.Join(dir, "client.key")})
+	require.NoError(t, err)
+	verdict := c.Review(context.Background(), newRequest(nil))
+	require.NoError(t, verdict.Err)
+	assert.Equal(t, Allow, verdict.Decision)
+
+	// without the client certificate the handshake fails and the review fails closed
+	c, err = New(Options{URL: srv.URL, CAFile: filepath.Join(dir, "ca.crt")})
+	require.NoError(t, err)
+	verdict = c.Review(context.Background(), newRequest(nil))
+	assert.Error(t, verdict.Err)
+	assert.Equal(t, Defer, verdict.Decision)
+}
+
+func TestNew(t *testing.T) {
+	c, err := New(Options{})
+	require.NoError(t, err)
+	assert.Nil(t, c)
+
+	_, err = New(Options{URL: "policy.example.com"})
+	assert.Error(t, err)
+	_, err = New(Options{URL: "https://policy.example.com", FailurePolicy: "sometimes"})
+	assert.Error(t, err)
+	_, err = New(Options{URL: "https://policy.example.com", CAFile: "/does/not/exist"})
+	assert.Error(t, err)
+}
+
+// newClientCertificate returns a self-signed CA pool with the PEM encoded client certificate and key it trusts
+func newClientCertificate(t *testing.T) (*x509.CertPool, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "local-pv-cleaner"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return pool, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetup_disabled(t *testing.T) {
+	before := otel.GetTracerProvider()
+	shutdown, err := Setup(context.Background(), Options{})
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+	assert.Equal(t, before, otel.GetTracerProvider(), "Expected the global tracer provider to be left alone")
+}
+
+func TestNewTracerProvider_sampling(t *testing.T) {
+	var tests = []struct {
+		name        string
+		sampleRatio float64
+		wantSpans   int
+	}{
+		{name: "Sample everything", sampleRatio: 1, wantSpans: 10},
+		{name: "Sample nothing", sampleRatio: 0, wantSpans: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			provider := NewTracerProvider(sdktrace.NewSimpleSpanProcessor(exporter), tt.sampleRatio)
+			for range 10 {
+				_, span := provider.Tracer("test").Start(context.Background(), "Reconcile")
+				span.End()
+			}
+			assert.Len(t, exporter.GetSpans(), tt.wantSpans)
+			assert.NoError(t, provider.Shutdown(context.Background()))
+		})
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package window
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a standard five field cron schedule, without the non-standard extensions
+type cronSchedule struct {
+	loc     *time.Location
+	minutes [60]bool
+	hours   [24]bool
+	days    [32]bool
+	months  [13]bool
+	// weekdays holds Sunday both as 0 and as 7
+	weekdays [8]bool
+	// anyDay and anyWeekday are set when the day of month or the day of week is *. When both are restricted,
+	// the schedule fires when either matches.
+	anyDay, anyWeekday bool
+}
+
+// cronField describes the range and names of a cron field
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDay    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: []string{"", "jan", "feb", "mar", "apr", "may",
+		"jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	cronWeekday = cronField{name: "day of week", min: 0, max: 7, names: weekdays}
+)
+
+// parseCronSchedule parses the five fields of a cron schedule
+func parseCronSchedule(fields []string) (*cronSchedule, error) {
+	s := &cronSchedule{anyDay: fields[2] == "*", anyWeekday: fields[4] == "*"}
+	for _, f := range []struct {
+		field cronField
+		value string
+		set   []bool
+	}{
+		{cronMinute, fields[0], s.minutes[:]},
+		{cronHour, fields[1], s.hours[:]},
+		{cronDay, fields[2], s.days[:]},
+		{cronMonth, fields[3], s.months[:]},
+		{cronWeekday, fields[4], s.weekdays[:]},
+	} {
+		if err := f.field.parse(f.value, f.set); err != nil {
+			return nil, err
+		}
+	}
+	if s.weekdays[7] {
+		s.weekdays[0] = true
+	}
+	return s, nil
+}
+
+// parse sets the values of the field matched by a comma-separated list of *, values and ranges with steps
+func (f cronField) parse(value string, set []bool) error {
+	for _, part := range strings.Split(value, ",") {
+		expr, stepValue, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepValue); err != nil || step <= 0 {
+				return fmt.Errorf("invalid step %q in the %s field", stepValue, f.name)
+			}
+		}
+
+		first, last := f.min, f.max
+		if expr != "*" {
+			from, to, isRange := strings.Cut(expr, "-")
+			var err error
+			if first, err = f.value(from); err != nil {
+				return err
+			}
+			last = first
+			if isRange {
+				if last, err = f.value(to); err != nil {
+					return err
+				}
+			} else if hasStep {
+				last = f.max
+			}
+			if last < first {
+				return fmt.Errorf("invalid range %q in the %s field", expr, f.name)
+			}
+		}
+		for i := first; i <= last; i += step {
+			set[i] = true
+		}
+	}
+	return nil
+}
+
+// value parses a single number or name of the field
+func (f cronField) value(value string) (int, error) {
+	for i, name := range f.names {
+		if name != "" && strings.EqualFold(value, name) {
+			return i, nil
+		}
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid value %q in the %s field, expected %d-%d", value, f.name, f.min, f.max)
+	}
+	return n, nil
+}
+
+// next returns the first time the schedule fires after the given time, zero if it does not within 5 years
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = t.In(s.loc)
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, s.loc)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !s.months[t.Month()]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.loc)
+		case !s.hours[t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.loc)
+		case !s.minutes[t.Minute()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, s.loc)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether the day of month or the day of week of t match, following the cron semantics
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	day, weekday := s.days[t.Day()], s.weekdays[t.Weekday()]
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
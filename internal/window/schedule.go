@@ -0,0 +1,72 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package window
+
+import (
+	"strings"
+	"time"
+)
+
+// Schedule is a set of maintenance windows, it is open when any of its windows is.
+// An empty Schedule is always open.
+type Schedule []*Window
+
+// ParseSchedule parses each of the given windows
+func ParseSchedule(sources []string) (Schedule, error) {
+	var s Schedule
+	for _, source := range sources {
+		w, err := Parse(source)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, w)
+	}
+	return s, nil
+}
+
+// Open reports whether a window is open at the given time
+func (s Schedule) Open(t time.Time) bool {
+	if len(s) == 0 {
+		return true
+	}
+	for _, w := range s {
+		if w.Active(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns when the next window opens after the given time, zero if none does
+func (s Schedule) Next(t time.Time) time.Time {
+	var next time.Time
+	for _, w := range s {
+		if start := w.NextStart(t); !start.IsZero() && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+	return next
+}
+
+// String returns the windows separated by semicolons
+func (s Schedule) String() string {
+	sources := make([]string, 0, len(s))
+	for _, w := range s {
+		sources = append(sources, w.String())
+	}
+	return strings.Join(sources, "; ")
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package window parses the maintenance windows restricting the destructive actions and computes
+// whether they are open and when they open next.
+package window
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// the controller image has no zoneinfo
+	_ "time/tzdata"
+)
+
+// Window is a recurring period of time in a time zone, either a weekday and time range such as
+// "Mon-Fri 09:00-17:00 Europe/Berlin", or a cron schedule opening the window for a duration such as
+// "cron 0 22 * * Sat 6h Europe/Berlin". The time zone is UTC when omitted.
+type Window struct {
+	source string
+	loc    *time.Location
+
+	// days, start and end describe a weekday and time range, in minutes of the day.
+	// The range spans midnight when the end is not after the start.
+	days       [7]bool
+	start, end int
+
+	// cron and duration describe a cron window
+	cron     *cronSchedule
+	duration time.Duration
+}
+
+// Parse parses a single window
+func Parse(source string) (*Window, error) {
+	fields := strings.Fields(source)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty maintenance window")
+	}
+
+	var w *Window
+	var err error
+	var rest []string
+	if fields[0] == "cron" {
+		if len(fields) < 7 {
+			return nil, fmt.Errorf("maintenance window %q: expected cron <minute> <hour> <day of month> <month> "+
+				"<day of week> <duration> [time zone]", source)
+		}
+		w, err = parseCron(fields[1:7])
+		rest = fields[7:]
+	} else {
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("maintenance window %q: expected <days> <HH:MM-HH:MM> [time zone]", source)
+		}
+		w, err = parseRange(fields[0], fields[1])
+		rest = fields[2:]
+	}
+	if err != nil {
+		return nil, fmt.Errorf("maintenance window %q: %w", source, err)
+	}
+
+	w.source = source
+	w.loc = time.UTC
+	switch len(rest) {
+	case 0:
+	case 1:
+		if w.loc, err = time.LoadLocation(rest[0]); err != nil {
+			return nil, fmt.Errorf("maintenance window %q: %w", source, err)
+		}
+	default:
+		return nil, fmt.Errorf("maintenance window %q: unexpected %q", source, strings.Join(rest[1:], " "))
+	}
+	if w.cron != nil {
+		w.cron.loc = w.loc
+		if w.cron.next(time.Now()).IsZero() {
+			return nil, fmt.Errorf("maintenance window %q: the cron schedule never fires", source)
+		}
+	}
+
+	return w, nil
+}
+
+// String returns the source of the window
+func (w *Window) String() string {
+	return w.source
+}
+
+// Active reports whether the window is open at the given time
+func (w *Window) Active(t time.Time) bool {
+	if w.cron != nil {
+		start := w.cron.next(t.Add(-w.duration))
+		return !start.IsZero() && !start.After(t)
+	}
+
+	local := t.In(w.loc)
+	minute := local.Hour()*60 + local.Minute()
+	today := w.days[local.Weekday()]
+	if w.start < w.end {
+		return today && minute >= w.start && minute < w.end
+	}
+	yesterday := w.days[(local.Weekday()+6)%7]
+	return (today && minute >= w.start) || (yesterday && minute < w.end)
+}
+
+// NextStart returns when the window opens next after the given time, zero if it never does
+func (w *Window) NextStart(t time.Time) time.Time {
+	if w.cron != nil {
+		return w.cron.next(t)
+	}
+
+	local := t.In(w.loc)
+	for i := 0; i <= 7; i++ {
+		start := time.Date(local.Year(), local.Month(), local.Day()+i, w.start/60, w.start%60, 0, 0, w.loc)
+		if start.After(t) && w.days[start.Weekday()] {
+			return start
+		}
+	}
+	return time.Time{}
+}
+
+// parseRange parses the days and the time range of a weekday and time range window
+func parseRange(days, times string) (*Window, error) {
+	w := &Window{}
+	if days == "*" {
+		w.days = [7]bool{true, true, true, true, true, true, true}
+	} else {
+		for _, part := range strings.Split(days, ",") {
+			from, to, isRange := strings.Cut(part, "-")
+			first, err := parseWeekday(from)
+			if err != nil {
+				return nil, err
+			}
+			last := first
+			if isRange {
+				if last, err = parseWeekday(to); err != nil {
+					return nil, err
+				}
+			}
+			for day := first; ; day = (day + 1) % 7 {
+				w.days[day] = true
+				if day == last {
+					break
+				}
+			}
+		}
+	}
+
+	from, to, ok := strings.Cut(times, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid time range %q, expected HH:MM-HH:MM", times)
+	}
+	var err error
+	if w.start, err = parseTimeOfDay(from); err != nil {
+		return nil, err
+	}
+	if w.end, err = parseTimeOfDay(to); err != nil {
+		return nil, err
+	}
+	if w.start == 24*60 || w.start == w.end {
+		return nil, fmt.Errorf("invalid time range %q", times)
+	}
+	w.end %= 24 * 60
+	return w, nil
+}
+
+// parseCron parses the schedule and the duration of a cron window
+func parseCron(fields []string) (*Window, error) {
+	schedule, err := parseCronSchedule(fields[:5])
+	if err != nil {
+		return nil, err
+	}
+	duration, err := time.ParseDuration(fields[5])
+	if err != nil {
+		return nil, err
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("invalid duration %q, expected a positive duration", fields[5])
+	}
+	return &Window{cron: schedule, duration: duration}, nil
+}
+
+var weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// parseWeekday parses an abbreviated English weekday name
+func parseWeekday(name string) (time.Weekday, error) {
+	for i, day := range weekdays {
+		if strings.EqualFold(name, day) {
+			return time.Weekday(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q, expected one of Sun, Mon, Tue, Wed, Thu, Fri, Sat", name)
+}
+
+// parseTimeOfDay parses HH:MM into minutes of the day, up to 24:00
+func parseTimeOfDay(value string) (int, error) {
+	hours, minutes, ok := strings.Cut(value, ":")
+	h, hErr := strconv.Atoi(hours)
+	m, mErr := strconv.Atoi(minutes)
+	if !ok || hErr != nil || mErr != nil || h < 0 || m < 0 || m > 59 || h*60+m > 24*60 {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return h*60 + m, nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 Kavinraja-G.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package window
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_errors(t *testing.T) {
+	var tests = []struct {
+		name    string
+		source  string
+		wantErr string
+	}{
+		{name: "Empty", source: " ", wantErr: "empty maintenance window"},
+		{name: "Missing time range", source: "Mon-Fri", wantErr: "expected <days>"},
+		{name: "Invalid weekday", source: "Mon-Fry 09:00-17:00", wantErr: `invalid weekday "Fry"`},
+		{name: "Invalid time", source: "Mon 9-17", wantErr: `invalid time "9"`},
+		{name: "Empty time range", source: "Mon 09:00-09:00", wantErr: `invalid time range "09:00-09:00"`},
+		{name: "Invalid time zone", source: "Mon 09:00-17:00 Mars/Olympus", wantErr: "unknown time zone"},
+		{name: "Trailing fields", source: "Mon 09:00-17:00 UTC now", wantErr: `unexpected "now"`},
+		{name: "Short cron", source: "cron 0 22 * * Sat", wantErr: "expected cron"},
+		{name: "Invalid cron value", source: "cron 0 25 * * * 1h", wantErr: `invalid value "25" in the hour field`},
+		{name: "Invalid cron step", source: "cron */0 * * * * 1h", wantErr: `invalid step "0"`},
+		{name: "Invalid duration", source: "cron 0 22 * * * -1h", wantErr: "expected a positive duration"},
+		{name: "Never fires", source: "cron 0 0 30 Feb * 1h", wantErr: "never fires"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.source)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// Saturday 1 March 2025
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 3, day, hour, minute, 0, 0, berlin)
+	}
+
+	var tests = []struct {
+		name          string
+		source        string
+		at            time.Time
+		wantActive    bool
+		wantNextStart time.Time
+	}{
+		{
+			name:          "Weekday range on a weekend",
+			source:        "Mon-Fri 09:00-17:00 Europe/Berlin",
+			at:            at(1, 10, 0),
+			wantNextStart: at(3, 9, 0),
+		},
+		{
+			name:          "Weekday range inside",
+			source:        "mon-fri 09:00-17:00 Europe/Berlin",
+			at:            at(3, 16, 59),
+			wantActive:    true,
+			wantNextStart: at(4, 9, 0),
+		},
+		{
+			name:          "Weekday range at its end",
+			source:        "Mon-Fri 09:00-17:00 Europe/Berlin",
+			at:            at(3, 17, 0),
+			wantNextStart: at(4, 9, 0),
+		},
+		{
+			name:          "Range in UTC by default",
+			source:        "Sat 09:00-17:00",
+			at:            at(1, 9, 30),
+			wantNextStart: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "Overnight range after midnight",
+			source:        "Fri,Sat 22:00-02:00 Europe/Berlin",
+			at:            at(2, 1, 30),
+			wantActive:    true,
+			wantNextStart: at(7, 22, 0),
+		},
+		{
+			name:          "Overnight range on the next day",
+			source:        "Fri 22:00-02:00 Europe/Berlin",
+			at:            at(1, 2, 0),
+			wantNextStart: at(7, 22, 0),
+		},
+		{
+			name:          "Wrapping weekday range until midnight",
+			source:        "Sat-Sun 20:00-24:00 Europe/Berlin",
+			at:            at(2, 23, 59),
+			wantActive:    true,
+			wantNextStart: at(8, 20, 0),
+		},
+		{
+			name:          "Every day",
+			source:        "* 00:00-24:00",
+			at:            at(5, 12, 0),
+			wantActive:    true,
+			wantNextStart: time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "Cron window inside",
+			source:        "cron 0 22 * * Sat 6h Europe/Berlin",
+			at:            at(2, 3, 59),
+			wantActive:    true,
+			wantNextStart: at(8, 22, 0),
+		},
+		{
+			name:          "Cron window at its end",
+			source:        "cron 0 22 * * 6 6h Europe/Berlin",
+			at:            at(2, 4, 0),
+			wantNextStart: at(8, 22, 0),
+		},
+		{
+			name:          "Cron window with day of month or day of week",
+			source:        "cron 30 9 15 * Mon 1h Europe/Berlin",
+			at:            at(3, 10, 30),
+			wantNextStart: at(10, 9, 30),
+		},
+		{
+			name:          "Cron window with steps",
+			source:        "cron */20 8-10/2 * Mar-Apr * 10m Europe/Berlin",
+			at:            at(1, 10, 50),
+			wantNextStart: at(2, 8, 0),
+		},
+		{
+			name:          "Cron window over the DST change",
+			source:        "cron 0 1 * * * 3h Europe/Berlin",
+			at:            at(30, 3, 59),
+			wantActive:    true,
+			wantNextStart: at(31, 1, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := Parse(tt.source)
+			require.NoError(t, err)
+			assert.Equal(t, tt.source, w.String())
+			assert.Equal(t, tt.wantActive, w.Active(tt.at))
+			assert.True(t, tt.wantNextStart.Equal(w.NextStart(tt.at)), "Expected the next start %s, got %s",
+				tt.wantNextStart, w.NextStart(tt.at))
+		})
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var empty Schedule
+	assert.True(t, empty.Open(at))
+	assert.True(t, empty.Next(at).IsZero())
+
+	s, err := ParseSchedule([]string{"Mon-Fri 09:00-17:00", "cron 0 20 * * * 1h"})
+	require.NoError(t, err)
+	assert.False(t, s.Open(at))
+	assert.Equal(t, time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC), s.Next(at))
+	assert.True(t, s.Open(at.Add(8*time.Hour+30*time.Minute)))
+	assert.Equal(t, "Mon-Fri 09:00-17:00; cron 0 20 * * * 1h", s.String())
+
+	_, err = ParseSchedule([]string{"Mon-Fri 09:00-17:00", "Funday 09:00-17:00"})
+	assert.Error(t, err)
+}